@@ -0,0 +1,83 @@
+package main
+
+// db schema migration
+//
+//	go run ./cmd/migrate [-config-file config/config.toml] status
+//	go run ./cmd/migrate up
+//	go run ./cmd/migrate down [steps, default 1]
+import (
+	"backend/config"
+	"backend/internal/pkg/model"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	cfg, err := config.New()
+	if err != nil {
+		return err
+	}
+
+	db, err := model.OpenDB(cfg)
+	if err != nil {
+		return err
+	}
+
+	migrator, err := model.NewMigrator(db)
+	if err != nil {
+		return err
+	}
+
+	switch flag.Arg(0) {
+	case "up":
+		if err := migrator.Up(); err != nil {
+			return err
+		}
+	case "down":
+		steps := 1
+		if flag.NArg() > 1 {
+			if steps, err = strconv.Atoi(flag.Arg(1)); err != nil || steps < 1 {
+				return fmt.Errorf("steps must be a positive integer: %q", flag.Arg(1))
+			}
+		}
+		if err := migrator.Down(steps); err != nil {
+			return err
+		}
+	case "status", "":
+	default:
+		return fmt.Errorf("unknown command %q, use up, down [steps] or status", flag.Arg(0))
+	}
+
+	return printStatus(migrator)
+}
+
+func printStatus(migrator *model.Migrator) error {
+	status, err := migrator.Status()
+	if err != nil {
+		return err
+	}
+
+	version, err := migrator.Version()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("version %d (latest %d)\n", version, migrator.Latest())
+
+	for _, s := range status {
+		applied := "pending"
+		if s.AppliedAt != nil {
+			applied = s.AppliedAt.Format("2006-01-02 15:04:05")
+		}
+		fmt.Printf("%04d  %-20s %s\n", s.Version, s.Name, applied)
+	}
+	return nil
+}
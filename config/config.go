@@ -4,6 +4,7 @@ import (
 	"flag"
 	"path"
 	"runtime"
+	"time"
 
 	"github.com/BurntSushi/toml"
 	"github.com/creasty/defaults"
@@ -19,20 +20,194 @@ type Postgre struct {
 	TimeZone string `toml:"timeZone"`
 }
 
+type MySQL struct {
+	Host     string `toml:"host" default:"127.0.0.1"`
+	Port     string `toml:"port" default:"3306"`
+	DBName   string `toml:"dbname"`
+	User     string `toml:"user"`
+	Password string `toml:"password"`
+	Charset  string `toml:"charset" default:"utf8mb4"`
+}
+
+// 모든 db 공통 connection pool
+//
+// 0(미설정)은 default 값으로 채워지므로 제한을 없애려면 음수(-1) 사용
+type DBPool struct {
+	// 음수면 open connection 수 제한 없음
+	MaxOpenConns int `toml:"maxOpenConns" default:"25"`
+	// 음수면 idle connection 을 유지하지 않음
+	MaxIdleConns int `toml:"maxIdleConns" default:"5"`
+	// 음수면 connection 재사용 시간 제한 없음
+	ConnMaxLifetime time.Duration `toml:"connMaxLifetime" default:"30m"`
+	ConnMaxIdleTime time.Duration `toml:"connMaxIdleTime" default:"5m"`
+	// 시작 시 연결 실패하면 connectBackoff 부터 2배씩 늘려가며 connectRetries 번 재시도, 음수면 재시도 없음
+	ConnectRetries int           `toml:"connectRetries" default:"5"`
+	ConnectBackoff time.Duration `toml:"connectBackoff" default:"1s"`
+	// 시작, /readyz 의 ping 제한 시간, 음수면 제한 없음
+	PingTimeout time.Duration `toml:"pingTimeout" default:"2s"`
+}
+
+// 외부 호출 1건의 제한 시간, request context 가 먼저 취소되면 그 시점에 중단
+//
+// 0(미설정)은 default 값으로 채워지므로 제한을 없애려면 음수(-1s) 사용
+type Timeouts struct {
+	GitClient time.Duration `toml:"gitClient" default:"10s"`
+	K8s       time.Duration `toml:"k8s" default:"10s"`
+	DB        time.Duration `toml:"db" default:"5s"`
+}
+
+// kubeconfig 내용을 직접 넣거나 파일 경로를 지정
+type Cluster struct {
+	Name           string `toml:"name"`
+	KubeConfig     string `toml:"kubeconfig"`
+	KubeConfigPath string `toml:"kubeconfigPath"`
+}
+
+// brokers 미설정 시 kafka 대신 log 로 message 출력, 설정 시 연결 실패는 시작 오류
+type Kafka struct {
+	Brokers  []string `toml:"brokers"`
+	ClientID string   `toml:"clientId" default:"go-echo"`
+	// kafka broker version (e.g. 2.8.0), 미설정 시 sarama 기본값
+	Version string `toml:"version"`
+	// none, leader, all
+	Acks string `toml:"acks" default:"all"`
+	// none, gzip, snappy, lz4, zstd
+	Compression string `toml:"compression" default:"none"`
+	Idempotent  bool   `toml:"idempotent"`
+	// true 면 async producer 사용, 전송 오류는 log 로 출력
+	Async bool `toml:"async"`
+
+	TLS  KafkaTLS  `toml:"tls"`
+	SASL KafkaSASL `toml:"sasl"`
+
+	AuditTopic string `toml:"auditTopic" default:"audit"`
+	// 전송 대기 audit event 최대 수, 가득 차면 새 event 는 버리고 log 출력
+	AuditQueueSize int `toml:"auditQueueSize" default:"1024"`
+
+	Consumer KafkaConsumer `toml:"consumer"`
+}
+
+// 처리 실패 message 는 maxRetries 만큼 재시도 후 <topic><deadLetterSuffix> 로 전송
+type KafkaConsumer struct {
+	Enabled bool   `toml:"enabled"`
+	GroupID string `toml:"groupId" default:"go-echo"`
+	// oldest, newest
+	InitialOffset    string        `toml:"initialOffset" default:"newest"`
+	MaxRetries       int           `toml:"maxRetries" default:"3"`
+	RetryBackoff     time.Duration `toml:"retryBackoff" default:"1s"`
+	DeadLetterSuffix string        `toml:"deadLetterSuffix" default:".dlq"`
+
+	// alert message 수신 시 alertOwner/alertRepo 에 issue 생성, 미설정 시 사용 안함
+	AlertTopic string `toml:"alertTopic" default:"alerts"`
+	AlertOwner string `toml:"alertOwner"`
+	AlertRepo  string `toml:"alertRepo"`
+}
+
+type KafkaTLS struct {
+	Enabled            bool   `toml:"enabled"`
+	CAFile             string `toml:"caFile"`
+	CertFile           string `toml:"certFile"`
+	KeyFile            string `toml:"keyFile"`
+	InsecureSkipVerify bool   `toml:"insecureSkipVerify"`
+}
+
+// mechanism: PLAIN, SCRAM-SHA-256, SCRAM-SHA-512
+type KafkaSASL struct {
+	Enabled   bool   `toml:"enabled"`
+	Mechanism string `toml:"mechanism" default:"SCRAM-SHA-512"`
+	User      string `toml:"user"`
+	Password  string `toml:"password"`
+}
+
+// jwt 비대칭 서명 key, PEM 파일 경로
+//
+// privateKeyPath 가 없는 key 는 교체 중인 이전 key 로 검증에만 사용
+type JWTKey struct {
+	ID             string `toml:"id"`
+	Algorithm      string `toml:"algorithm" default:"RS256"`
+	PrivateKeyPath string `toml:"privateKeyPath"`
+	PublicKeyPath  string `toml:"publicKeyPath"`
+}
+
+// OpenID Connect provider (authorization code + PKCE)
+type OIDC struct {
+	Enabled      bool     `toml:"enabled"`
+	Issuer       string   `toml:"issuer"`
+	ClientID     string   `toml:"clientId"`
+	ClientSecret string   `toml:"clientSecret"`
+	RedirectURL  string   `toml:"redirectUrl"`
+	Scopes       []string `toml:"scopes"`
+	// 사용자 이름, group 목록을 읽을 id token claim
+	NameClaim   string `toml:"nameClaim" default:"preferred_username"`
+	GroupsClaim string `toml:"groupsClaim" default:"groups"`
+	// group -> role(admin, developer, viewer), 매핑되는 group 이 없으면 viewer
+	RoleMapping map[string]string `toml:"roleMapping"`
+}
+
+// 인증 없이 호출 가능한 경로, method 미입력 시 전체 method
+//
+// path 의 * 는 한 단계, 마지막 ** 는 하위 경로 전체 (e.g. /swagger/**)
+type PublicRoute struct {
+	Method string `toml:"method"`
+	Path   string `toml:"path"`
+}
+
+// token bucket, 초당 rate 개 충전, 최대 burst 개
+type RateLimitRule struct {
+	Rate  float64 `toml:"rate"`
+	Burst int     `toml:"burst"`
+}
+
+// jwt subject, api key, client ip 별 요청 제한
+type RateLimit struct {
+	Enabled bool          `toml:"enabled"`
+	Default RateLimitRule `toml:"default"`
+	// route group(login, github, k8s, user, apikeys) 별 설정, 없으면 default 사용
+	Groups map[string]RateLimitRule `toml:"groups"`
+	// 연속 login 실패 시 계정 잠금
+	LoginMaxFailures int           `toml:"loginMaxFailures" default:"5"`
+	LoginLockout     time.Duration `toml:"loginLockout" default:"15m"`
+}
+
 type Config struct {
 	Listen        string `toml:"listen"`
 	Phase         string `toml:"phase"`
 	JWTSigningKey string `toml:"jwtSigningKey"`
+	// jwtKeys 가 있으면 jwtSigningKey(HS256) 대신 사용, jwtActiveKey 로 서명
+	JWTActiveKey string   `toml:"jwtActiveKey"`
+	JWTKeys      []JWTKey `toml:"jwtKeys"`
+	// access token 은 짧게, refresh token 은 server 에 저장하고 길게 유지
+	AccessTokenTTL  time.Duration `toml:"accessTokenTTL" default:"15m"`
+	RefreshTokenTTL time.Duration `toml:"refreshTokenTTL" default:"720h"`
+	// api key 생성 시 만료 시각을 지정하지 않으면 사용
+	APIKeyTTL time.Duration `toml:"apiKeyTTL" default:"2160h"`
+	// 최초 login 용 계정, 같은 이름의 사용자가 없으면 시작 시 생성
+	AdminName     string `toml:"adminName"`
+	AdminPassword string `toml:"adminPassword"`
+	OIDC          OIDC   `toml:"oidc"`
+	// login 등 route 에서 security.Public 으로 표시한 경로 외 추가 public 경로
+	PublicRoutes []PublicRoute `toml:"publicRoutes"`
+	RateLimit    RateLimit     `toml:"rateLimit"`
 
 	GitClient   string `toml:"gitClient"`
 	GitHubToken string `toml:"githubToken"`
 	GitLabToken string `toml:"gitlabToken"`
 
-	DB           string `toml:"db"`
+	// sqlite, postgre, mysql 외의 값은 시작 시 error
+	DB           string `toml:"db" default:"sqlite"`
 	SqliteDBPath string `toml:"sqliteDBPath"`
 	Postgre      `toml:"postgre"`
+	MySQL        MySQL  `toml:"mysql"`
+	DBPool       DBPool `toml:"dbPool"`
+
+	// deprecated: clusters 미설정 시 "default" cluster 로 등록
+	ClusterToken   string    `toml:"clusterToken"`
+	DefaultCluster string    `toml:"defaultCluster"`
+	Clusters       []Cluster `toml:"clusters"`
+
+	Kafka Kafka `toml:"kafka"`
 
-	ClusterToken string `toml:"clusterToken"`
+	Timeouts Timeouts `toml:"timeouts"`
 }
 
 func New() (Config, error) {
@@ -0,0 +1,14 @@
+package ctxutil
+
+import (
+	"context"
+	"time"
+)
+
+// ctx 에 호출 1건의 제한 시간 적용, timeout 이 0 이하면 제한 없이 cancel 만 가능한 ctx 반환
+func WithTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
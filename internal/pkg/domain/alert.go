@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// alert topic 으로 수신하는 json message
+//
+// issue 는 항상 handler 에 설정된 repo 에 생성, message 로 대상 repo 를 지정할 수 없음
+type AlertMessage struct {
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Severity    string   `json:"severity"`
+	Source      string   `json:"source"`
+	Labels      []string `json:"labels,omitempty"`
+}
+
+// alert message 를 git issue 로 생성하는 handler
+func NewAlertIssueHandler(client GitClientHandler, owner, repo string) MessageHandler {
+	return func(ctx context.Context, message *Message) error {
+		alert := &AlertMessage{}
+		if err := json.Unmarshal(message.Payload, alert); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidMessage, err)
+		}
+		if alert.Title == "" {
+			return fmt.Errorf("%w: alert title is empty", ErrInvalidMessage)
+		}
+
+		if owner == "" || repo == "" {
+			return fmt.Errorf("%w: alert repo is not configured", ErrInvalidMessage)
+		}
+
+		issue, err := client.CreateIssue(ctx, owner, repo, alertIssueRequest(alert))
+		if err != nil {
+			return err
+		}
+
+		log.Printf("alert %q created issue %q on %s/%s", alert.Title, issue.Title, owner, repo)
+		return nil
+	}
+}
+
+func alertIssueRequest(alert *AlertMessage) *CreateGitIssueRequest {
+	title := alert.Title
+	if alert.Severity != "" {
+		title = fmt.Sprintf("[%s] %s", strings.ToUpper(alert.Severity), alert.Title)
+	}
+
+	var body strings.Builder
+	body.WriteString(alert.Description)
+	if alert.Source != "" {
+		if body.Len() > 0 {
+			body.WriteString("\n\n")
+		}
+		body.WriteString("source: " + alert.Source)
+	}
+
+	labels := append([]string{"alert"}, alert.Labels...)
+	if alert.Severity != "" {
+		labels = append(labels, "severity:"+strings.ToLower(alert.Severity))
+	}
+
+	return &CreateGitIssueRequest{
+		Title:  title,
+		Body:   body.String(),
+		Labels: labels,
+	}
+}
@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"backend/config"
+	"log"
+	"sync"
+	"time"
+)
+
+const (
+	AuditOutcomeSuccess = "success"
+	AuditOutcomeFailure = "failure"
+)
+
+// 변경 API 호출 기록
+type AuditEvent struct {
+	Actor     string    `json:"actor"`
+	Action    string    `json:"action"`
+	Target    string    `json:"target"`
+	Timestamp time.Time `json:"timestamp"`
+	Outcome   string    `json:"outcome"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// cfg.Kafka.AuditQueueSize 미설정 시 사용
+const defaultAuditQueueSize = 1024
+
+// audit event 를 json 으로 직렬화하여 audit topic 으로 전송
+//
+// 요청 처리 시간이 broker 응답에 영향받지 않도록 queue 에 넣고 background goroutine 에서 전송
+type AuditPublisher struct {
+	sender MessageSender
+	topic  string
+
+	mu     sync.RWMutex
+	closed bool
+	queue  chan *Message
+	done   chan struct{}
+}
+
+func NewAuditPublisher(sender MessageSender, cfg config.Config) *AuditPublisher {
+	size := cfg.Kafka.AuditQueueSize
+	if size <= 0 {
+		size = defaultAuditQueueSize
+	}
+
+	a := &AuditPublisher{
+		sender: sender,
+		topic:  cfg.Kafka.AuditTopic,
+		queue:  make(chan *Message, size),
+		done:   make(chan struct{}),
+	}
+	go a.run()
+	return a
+}
+
+// Close 로 queue 가 닫히면 남은 event 전송 후 반환
+func (a *AuditPublisher) run() {
+	defer close(a.done)
+	for message := range a.queue {
+		if err := a.sender.SendMessage(message); err != nil {
+			log.Printf("audit event on %s was not sent: %v", message.Key, err)
+		}
+	}
+}
+
+// err 가 nil 이 아니면 failure 로 기록
+//
+// 전송을 기다리지 않음, queue 가 가득 차거나 전송 실패 시 API 응답에 영향을 주지 않도록 log 만 남김
+func (a *AuditPublisher) Publish(actor, action, target string, err error) {
+	if a == nil {
+		return
+	}
+
+	event := &AuditEvent{
+		Actor:     actor,
+		Action:    action,
+		Target:    target,
+		Timestamp: time.Now().UTC(),
+		Outcome:   AuditOutcomeSuccess,
+	}
+	if err != nil {
+		event.Outcome = AuditOutcomeFailure
+		event.Error = err.Error()
+	}
+
+	// 같은 대상의 event 는 순서 보장을 위해 target 을 key 로 사용
+	message, err := NewJSONMessage(a.topic, target, event)
+	if err != nil {
+		log.Printf("audit event marshal returned error: %v", err)
+		return
+	}
+
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.closed {
+		log.Printf("audit event %s on %s was not sent: %v", action, target, ErrMessageSenderClosed)
+		return
+	}
+
+	select {
+	case a.queue <- message:
+	default:
+		log.Printf("audit queue is full, event %s on %s was dropped", action, target)
+	}
+}
+
+// queue 에 남은 event 를 전송한 후 종료, message sender 를 닫기 전에 호출
+func (a *AuditPublisher) Close() error {
+	a.mu.Lock()
+	if a.closed {
+		a.mu.Unlock()
+		return nil
+	}
+	a.closed = true
+	close(a.queue)
+	a.mu.Unlock()
+
+	<-a.done
+	return nil
+}
@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"backend/config"
+	"encoding/json"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testMessageSender struct {
+	messages []*Message
+}
+
+func (s *testMessageSender) SendMessage(message *Message) error {
+	s.messages = append(s.messages, message)
+	return nil
+}
+
+func (s *testMessageSender) Close() error {
+	return nil
+}
+
+func TestAuditPublisher(t *testing.T) {
+	assert := assert.New(t)
+
+	sender := &testMessageSender{}
+	audit := NewAuditPublisher(sender, config.Config{Kafka: config.Kafka{AuditTopic: "audit"}})
+
+	audit.Publish("admin", "repo.delete", "jaemocho/test", nil)
+	audit.Publish("admin", "repo.delete", "jaemocho/test", errors.New("not found"))
+
+	// 대기 중인 event 전송 후 종료, 종료 후 event 는 무시
+	assert.NoError(audit.Close())
+	audit.Publish("admin", "repo.delete", "jaemocho/test", nil)
+
+	if !assert.Equal(2, len(sender.messages)) {
+		return
+	}
+
+	assert.Equal("audit", sender.messages[0].Topic)
+	assert.Equal("jaemocho/test", sender.messages[0].Key)
+	assert.Equal(ContentTypeJSON, sender.messages[0].ContentType)
+
+	event := &AuditEvent{}
+	assert.NoError(json.Unmarshal(sender.messages[0].Payload, event))
+	assert.Equal("admin", event.Actor)
+	assert.Equal("repo.delete", event.Action)
+	assert.Equal("jaemocho/test", event.Target)
+	assert.Equal(AuditOutcomeSuccess, event.Outcome)
+	assert.False(event.Timestamp.IsZero())
+
+	event = &AuditEvent{}
+	assert.NoError(json.Unmarshal(sender.messages[1].Payload, event))
+	assert.Equal(AuditOutcomeFailure, event.Outcome)
+	assert.Equal("not found", event.Error)
+
+	// publisher 미설정 시 무시
+	var nilAudit *AuditPublisher
+	nilAudit.Publish("admin", "repo.delete", "jaemocho/test", nil)
+}
+
+// 전송이 끝나지 않는 sender
+type blockingMessageSender struct {
+	release chan struct{}
+	sent    int32
+}
+
+func (s *blockingMessageSender) SendMessage(message *Message) error {
+	<-s.release
+	atomic.AddInt32(&s.sent, 1)
+	return nil
+}
+
+func (s *blockingMessageSender) Close() error {
+	return nil
+}
+
+func TestAuditPublisherQueue(t *testing.T) {
+	assert := assert.New(t)
+
+	sender := &blockingMessageSender{release: make(chan struct{})}
+	audit := NewAuditPublisher(sender, config.Config{Kafka: config.Kafka{AuditTopic: "audit", AuditQueueSize: 2}})
+
+	// broker 응답을 기다리지 않고, queue 가 가득 차면 버림
+	start := time.Now()
+	for i := 0; i < 10; i++ {
+		audit.Publish("admin", "user.create", "user/1", nil)
+	}
+	assert.Less(time.Since(start), time.Second)
+
+	close(sender.release)
+	assert.NoError(audit.Close())
+
+	// 전송 중 1건 + queue 2건
+	sent := atomic.LoadInt32(&sender.sent)
+	assert.GreaterOrEqual(sent, int32(2))
+	assert.LessOrEqual(sent, int32(3))
+}
@@ -1,16 +1,21 @@
 package domain
 
-import "backend/config"
+import (
+	"backend/config"
+	"context"
+)
 
 // Github/GitLab/bitbucket client interface
+//
+// ctx 가 취소되면 진행 중인 api 호출도 중단, 호출별 제한 시간은 cfg.Timeouts.GitClient
 type GitClientHandler interface {
-	GetRepoList(owner string) ([]*GitRepo, error)
-	GetWorkflowList(owner, repo string) ([]*GitWorkflow, error)
-	CreateWorkflowDispatchEventByFileName(owner, repo, workflowFileName, branch string, inputs map[string]interface{}) error
-	CreateRepo(createGitRepoRequest *CreateGitRepoRequest) (*GitRepo, error)
-	DeleteRepo(owner, repo string) error
-	CreateIssue(owner, repo string, issueRequest *CreateGitIssueRequest) (*GitIssue, error)
-	GetIssueList(owner, repo string) ([]*GitIssue, error)
+	GetRepoList(ctx context.Context, owner string) ([]*GitRepo, error)
+	GetWorkflowList(ctx context.Context, owner, repo string) ([]*GitWorkflow, error)
+	CreateWorkflowDispatchEventByFileName(ctx context.Context, owner, repo, workflowFileName, branch string, inputs map[string]interface{}) error
+	CreateRepo(ctx context.Context, createGitRepoRequest *CreateGitRepoRequest) (*GitRepo, error)
+	DeleteRepo(ctx context.Context, owner, repo string) error
+	CreateIssue(ctx context.Context, owner, repo string, issueRequest *CreateGitIssueRequest) (*GitIssue, error)
+	GetIssueList(ctx context.Context, owner, repo string) ([]*GitIssue, error)
 }
 
 type GitRepo struct {
@@ -48,6 +53,12 @@ type CreateGitIssueRequest struct {
 	Assignee string   `json:"assignee,omitempty"`
 }
 
+// ref 는 workflow 를 실행할 branch 또는 tag
+type CreateWorkflowDispatchRequest struct {
+	Ref    string                 `json:"ref"`
+	Inputs map[string]interface{} `json:"inputs,omitempty"`
+}
+
 func NewGitClientHandler(cfg config.Config) GitClientHandler {
 	if cfg.GitClient == "github" {
 		return NewGithubClientHandler(cfg)
@@ -2,15 +2,18 @@ package domain
 
 import (
 	"backend/config"
+	"backend/internal/pkg/ctxutil"
 	"context"
 	"log"
+	"time"
 
 	"github.com/google/go-github/v50/github"
 	"golang.org/x/oauth2"
 )
 
 type GithubClientHandler struct {
-	client *github.Client
+	client  *github.Client
+	timeout time.Duration
 }
 
 func NewGithubClientHandler(cfg config.Config) GitClientHandler {
@@ -24,16 +27,20 @@ func NewGithubClientHandler(cfg config.Config) GitClientHandler {
 	client := github.NewClient(tc)
 
 	return &GithubClientHandler{
-		client: client,
+		client:  client,
+		timeout: cfg.Timeouts.GitClient,
 	}
 }
 
 // onwer를 넣으면 url 기반으로 가져와서 private 이 보이지 않고
 //
 // owner를 넣지 않으면 token 기반으로 가져와서 private 까지 확인 가능
-func (g *GithubClientHandler) GetRepoList(owner string) ([]*GitRepo, error) {
+func (g *GithubClientHandler) GetRepoList(ctx context.Context, owner string) ([]*GitRepo, error) {
+	ctx, cancel := ctxutil.WithTimeout(ctx, g.timeout)
+	defer cancel()
+
 	opts := &github.RepositoryListOptions{Type: "owner", Sort: "updated", Direction: "desc"}
-	repos, _, err := g.client.Repositories.List(context.Background(), owner, opts)
+	repos, _, err := g.client.Repositories.List(ctx, owner, opts)
 	if err != nil {
 		log.Printf("Repositories.List returned error: %v", err)
 		return nil, err
@@ -51,9 +58,11 @@ func (g *GithubClientHandler) GetRepoList(owner string) ([]*GitRepo, error) {
 	return gitRepo, err
 }
 
-func (g *GithubClientHandler) GetWorkflowList(owner, repo string) ([]*GitWorkflow, error) {
+func (g *GithubClientHandler) GetWorkflowList(ctx context.Context, owner, repo string) ([]*GitWorkflow, error) {
 
-	workflows, _, err := g.client.Actions.ListWorkflows(context.Background(), owner, repo, nil)
+	ctx, cancel := ctxutil.WithTimeout(ctx, g.timeout)
+	defer cancel()
+	workflows, _, err := g.client.Actions.ListWorkflows(ctx, owner, repo, nil)
 	if err != nil {
 		log.Printf("Actions.ListWorkflows returned error: %v", err)
 		return nil, err
@@ -77,7 +86,7 @@ func createWorkflowList(workflows []*github.Workflow, cnt int) []*GitWorkflow {
 	return gitWorkFlow
 }
 
-func (g *GithubClientHandler) CreateWorkflowDispatchEventByFileName(owner, repo, workflowFileName, branch string, inputs map[string]interface{}) error {
+func (g *GithubClientHandler) CreateWorkflowDispatchEventByFileName(ctx context.Context, owner, repo, workflowFileName, branch string, inputs map[string]interface{}) error {
 
 	event := github.CreateWorkflowDispatchEventRequest{
 		Ref:    branch,
@@ -87,7 +96,9 @@ func (g *GithubClientHandler) CreateWorkflowDispatchEventByFileName(owner, repo,
 		//  },
 	}
 
-	_, err := g.client.Actions.CreateWorkflowDispatchEventByFileName(context.Background(), owner, repo, workflowFileName, event)
+	ctx, cancel := ctxutil.WithTimeout(ctx, g.timeout)
+	defer cancel()
+	_, err := g.client.Actions.CreateWorkflowDispatchEventByFileName(ctx, owner, repo, workflowFileName, event)
 	if err != nil {
 		log.Printf("Actions.CreateWorkflowDispatchEventByFileName returned error: %v", err)
 		return err
@@ -96,7 +107,7 @@ func (g *GithubClientHandler) CreateWorkflowDispatchEventByFileName(owner, repo,
 	return nil
 }
 
-func (g *GithubClientHandler) CreateRepo(createGitRepoRequest *CreateGitRepoRequest) (*GitRepo, error) {
+func (g *GithubClientHandler) CreateRepo(ctx context.Context, createGitRepoRequest *CreateGitRepoRequest) (*GitRepo, error) {
 
 	r := &github.Repository{
 		Name:        &createGitRepoRequest.Name,
@@ -105,7 +116,9 @@ func (g *GithubClientHandler) CreateRepo(createGitRepoRequest *CreateGitRepoRequ
 		AutoInit:    &createGitRepoRequest.IsAutoInt,
 	}
 
-	repo, _, err := g.client.Repositories.Create(context.Background(), "", r)
+	ctx, cancel := ctxutil.WithTimeout(ctx, g.timeout)
+	defer cancel()
+	repo, _, err := g.client.Repositories.Create(ctx, "", r)
 
 	if err != nil {
 		log.Printf("Repositories.Create returned error: %v", err)
@@ -121,9 +134,11 @@ func (g *GithubClientHandler) CreateRepo(createGitRepoRequest *CreateGitRepoRequ
 	return gitRepo, err
 }
 
-func (g *GithubClientHandler) DeleteRepo(owner, repo string) error {
+func (g *GithubClientHandler) DeleteRepo(ctx context.Context, owner, repo string) error {
 
-	_, err := g.client.Repositories.Delete(context.Background(), owner, repo)
+	ctx, cancel := ctxutil.WithTimeout(ctx, g.timeout)
+	defer cancel()
+	_, err := g.client.Repositories.Delete(ctx, owner, repo)
 
 	if err != nil {
 		log.Printf("Repositories.Delete returned error: %v", err)
@@ -133,7 +148,7 @@ func (g *GithubClientHandler) DeleteRepo(owner, repo string) error {
 	return nil
 }
 
-func (g *GithubClientHandler) CreateIssue(owner, repo string, issueRequest *CreateGitIssueRequest) (*GitIssue, error) {
+func (g *GithubClientHandler) CreateIssue(ctx context.Context, owner, repo string, issueRequest *CreateGitIssueRequest) (*GitIssue, error) {
 
 	issue := &github.IssueRequest{
 		Title:    &issueRequest.Title,
@@ -142,7 +157,9 @@ func (g *GithubClientHandler) CreateIssue(owner, repo string, issueRequest *Crea
 		Labels:   &issueRequest.Labels,
 	}
 
-	newIssue, _, err := g.client.Issues.Create(context.Background(), owner, repo, issue)
+	ctx, cancel := ctxutil.WithTimeout(ctx, g.timeout)
+	defer cancel()
+	newIssue, _, err := g.client.Issues.Create(ctx, owner, repo, issue)
 
 	if err != nil {
 		log.Printf("Issues.Create returned error: %v", err)
@@ -161,10 +178,12 @@ func (g *GithubClientHandler) CreateIssue(owner, repo string, issueRequest *Crea
 	return gitIssue, err
 }
 
-func (g *GithubClientHandler) GetIssueList(owner, repo string) ([]*GitIssue, error) {
-	opts := &github.IssueListByRepoOptions{Sort: "created", Direction: "desc"}
+func (g *GithubClientHandler) GetIssueList(ctx context.Context, owner, repo string) ([]*GitIssue, error) {
+	ctx, cancel := ctxutil.WithTimeout(ctx, g.timeout)
+	defer cancel()
 
-	issueList, _, err := g.client.Issues.ListByRepo(context.Background(), owner, repo, opts)
+	opts := &github.IssueListByRepoOptions{Sort: "created", Direction: "desc"}
+	issueList, _, err := g.client.Issues.ListByRepo(ctx, owner, repo, opts)
 
 	if err != nil {
 		log.Printf("Issues.ListByRepo returned error: %v", err)
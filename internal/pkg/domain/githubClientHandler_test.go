@@ -2,6 +2,7 @@ package domain
 
 import (
 	"backend/config"
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -20,7 +21,7 @@ func TestGitHhubClientHandler(t *testing.T) {
 	gh := NewGithubClientHandler(cfg)
 
 	// repo list 조회 test
-	repos, err := gh.GetRepoList("jaemocho")
+	repos, err := gh.GetRepoList(context.Background(), "jaemocho")
 	assert.NoError(err)
 	for i, v := range repos {
 		t.Log(i, v.Name)
@@ -28,7 +29,7 @@ func TestGitHhubClientHandler(t *testing.T) {
 	}
 
 	// workflow list 조회 test
-	workflows, err := gh.GetWorkflowList("jaemocho", "Study-WebFlux_3")
+	workflows, err := gh.GetWorkflowList(context.Background(), "jaemocho", "Study-WebFlux_3")
 	assert.NoError(err)
 	for i, v := range workflows {
 		t.Log(i, v.Id, v.Name)
@@ -50,12 +51,12 @@ func TestGitHhubClientHandler2(t *testing.T) {
 		IsAutoInt:   false,
 	}
 
-	repo, err := gh.CreateRepo(createGitRepoRequest)
+	repo, err := gh.CreateRepo(context.Background(), createGitRepoRequest)
 	assert.NoError(err)
 	assert.Equal("maketest123", repo.Name)
 
 	// delete repo test
-	// err = gh.DeleteRepo("jaemocho", "maketest123")
+	// err = gh.DeleteRepo(context.Background(), "jaemocho", "maketest123")
 	// assert.NoError(err)
 }
 
@@ -64,7 +65,7 @@ func TestWorkflowRun(t *testing.T) {
 
 	gh := NewGithubClientHandler(cfg)
 
-	err := gh.CreateWorkflowDispatchEventByFileName("jaemocho", "Study-WebFlux_3", "maven-publish.yml", "master", nil)
+	err := gh.CreateWorkflowDispatchEventByFileName(context.Background(), "jaemocho", "Study-WebFlux_3", "maven-publish.yml", "master", nil)
 	assert.NoError(err)
 }
 
@@ -79,11 +80,11 @@ func TestIssue(t *testing.T) {
 	labels := []string{"bug", "number"}
 	inputIssue := &CreateGitIssueRequest{Title: title, Body: body, Assignee: assignee, Labels: labels}
 
-	newIssue, err := gh.CreateIssue("jaemocho", "Study-WebFlux_3", inputIssue)
+	newIssue, err := gh.CreateIssue(context.Background(), "jaemocho", "Study-WebFlux_3", inputIssue)
 	assert.NoError(err)
 	assert.Equal("test", newIssue.Title)
 
-	issueList, err := gh.GetIssueList("jaemocho", "Study-WebFlux_3")
+	issueList, err := gh.GetIssueList(context.Background(), "jaemocho", "Study-WebFlux_3")
 	assert.NoError(err)
 	for _, v := range issueList {
 		t.Log(v.Title, v.Body, v.Labels)
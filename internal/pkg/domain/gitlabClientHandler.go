@@ -2,14 +2,18 @@ package domain
 
 import (
 	"backend/config"
+	"backend/internal/pkg/ctxutil"
+	"context"
 	"log"
 	"strconv"
+	"time"
 
 	"github.com/xanzy/go-gitlab"
 )
 
 type GitlabClientHandler struct {
-	client *gitlab.Client
+	client  *gitlab.Client
+	timeout time.Duration
 }
 
 func NewGitlabClientHandler(cfg config.Config) GitClientHandler {
@@ -20,13 +24,16 @@ func NewGitlabClientHandler(cfg config.Config) GitClientHandler {
 	}
 
 	return &GitlabClientHandler{
-		client: client,
+		client:  client,
+		timeout: cfg.Timeouts.GitClient,
 	}
 }
 
-func (g *GitlabClientHandler) GetRepoList(owner string) ([]*GitRepo, error) {
+func (g *GitlabClientHandler) GetRepoList(ctx context.Context, owner string) ([]*GitRepo, error) {
 
-	projects, _, err := g.client.Projects.ListUserProjects(owner, nil)
+	ctx, cancel := ctxutil.WithTimeout(ctx, g.timeout)
+	defer cancel()
+	projects, _, err := g.client.Projects.ListUserProjects(owner, nil, gitlab.WithContext(ctx))
 	if err != nil {
 		log.Printf("Projects.ListProjects returned error: %v", err)
 		return nil, err
@@ -45,17 +52,17 @@ func (g *GitlabClientHandler) GetRepoList(owner string) ([]*GitRepo, error) {
 	return gitRepos, nil
 }
 
-func (g *GitlabClientHandler) GetWorkflowList(owner, repo string) ([]*GitWorkflow, error) {
+func (g *GitlabClientHandler) GetWorkflowList(ctx context.Context, owner, repo string) ([]*GitWorkflow, error) {
 
 	return nil, nil
 }
 
-func (g *GitlabClientHandler) CreateWorkflowDispatchEventByFileName(owner, repo, workflowFileName, branch string, inputs map[string]interface{}) error {
+func (g *GitlabClientHandler) CreateWorkflowDispatchEventByFileName(ctx context.Context, owner, repo, workflowFileName, branch string, inputs map[string]interface{}) error {
 
 	return nil
 }
 
-func (g *GitlabClientHandler) CreateRepo(createGitRepoRequest *CreateGitRepoRequest) (*GitRepo, error) {
+func (g *GitlabClientHandler) CreateRepo(ctx context.Context, createGitRepoRequest *CreateGitRepoRequest) (*GitRepo, error) {
 
 	var visibility *gitlab.VisibilityValue
 	if !createGitRepoRequest.IsPrivate {
@@ -71,7 +78,9 @@ func (g *GitlabClientHandler) CreateRepo(createGitRepoRequest *CreateGitRepoRequ
 		InitializeWithReadme: &createGitRepoRequest.IsAutoInt,
 	}
 
-	project, _, err := g.client.Projects.CreateProject(opt)
+	ctx, cancel := ctxutil.WithTimeout(ctx, g.timeout)
+	defer cancel()
+	project, _, err := g.client.Projects.CreateProject(opt, gitlab.WithContext(ctx))
 
 	if err != nil {
 		log.Printf("Projects.CreateProject returned error: %v", err)
@@ -87,9 +96,11 @@ func (g *GitlabClientHandler) CreateRepo(createGitRepoRequest *CreateGitRepoRequ
 	return gitRepo, err
 }
 
-func (g *GitlabClientHandler) DeleteRepo(owner, repo string) error {
+func (g *GitlabClientHandler) DeleteRepo(ctx context.Context, owner, repo string) error {
 
-	_, err := g.client.Projects.DeleteProject(owner + "/" + repo)
+	ctx, cancel := ctxutil.WithTimeout(ctx, g.timeout)
+	defer cancel()
+	_, err := g.client.Projects.DeleteProject(owner+"/"+repo, gitlab.WithContext(ctx))
 	if err != nil {
 		log.Printf("Repositories.Delete returned error: %v", err)
 		return err
@@ -98,7 +109,7 @@ func (g *GitlabClientHandler) DeleteRepo(owner, repo string) error {
 
 }
 
-func (g *GitlabClientHandler) CreateIssue(owner, repo string, issueRequest *CreateGitIssueRequest) (*GitIssue, error) {
+func (g *GitlabClientHandler) CreateIssue(ctx context.Context, owner, repo string, issueRequest *CreateGitIssueRequest) (*GitIssue, error) {
 
 	var labels gitlab.Labels = issueRequest.Labels
 
@@ -108,7 +119,9 @@ func (g *GitlabClientHandler) CreateIssue(owner, repo string, issueRequest *Crea
 		Labels:      &labels,
 	}
 
-	newIssue, _, err := g.client.Issues.CreateIssue(owner+"/"+repo, issue)
+	ctx, cancel := ctxutil.WithTimeout(ctx, g.timeout)
+	defer cancel()
+	newIssue, _, err := g.client.Issues.CreateIssue(owner+"/"+repo, issue, gitlab.WithContext(ctx))
 
 	if err != nil {
 		log.Printf("Issues.CreateIssue returned error: %v", err)
@@ -126,9 +139,11 @@ func (g *GitlabClientHandler) CreateIssue(owner, repo string, issueRequest *Crea
 	return gitIssue, err
 }
 
-func (g *GitlabClientHandler) GetIssueList(owner, repo string) ([]*GitIssue, error) {
+func (g *GitlabClientHandler) GetIssueList(ctx context.Context, owner, repo string) ([]*GitIssue, error) {
 
-	issueList, _, err := g.client.Issues.ListProjectIssues(owner+"/"+repo, nil)
+	ctx, cancel := ctxutil.WithTimeout(ctx, g.timeout)
+	defer cancel()
+	issueList, _, err := g.client.Issues.ListProjectIssues(owner+"/"+repo, nil, gitlab.WithContext(ctx))
 
 	if err != nil {
 		log.Printf("Issues.ListProjectIssues returned error: %v", err)
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"backend/config"
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -20,7 +21,7 @@ func TestGitlabGetRepos(t *testing.T) {
 	gh := NewGitlabClientHandler(cfgLab)
 
 	// repo list 조회 test
-	repos, err := gh.GetRepoList("mot882000")
+	repos, err := gh.GetRepoList(context.Background(), "mot882000")
 	assert.NoError(err)
 	for i, v := range repos {
 		t.Log(i, v.Name, v.Description)
@@ -40,12 +41,12 @@ func TestGitlabCreateRepo(t *testing.T) {
 		IsPrivate:   false,
 		IsAutoInt:   false,
 	}
-	repo, err := gh.CreateRepo(createGitRepoRequest)
+	repo, err := gh.CreateRepo(context.Background(), createGitRepoRequest)
 	assert.NoError(err)
 	assert.Equal("maketest123", repo.Name)
 
 	// delete repo test
-	err = gh.DeleteRepo("mot882000", "maketest123")
+	err = gh.DeleteRepo(context.Background(), "mot882000", "maketest123")
 	assert.NoError(err)
 }
 
@@ -60,12 +61,12 @@ func TestCreateIssue(t *testing.T) {
 	labels := []string{"bug", "number", "ttt"}
 	inputIssue := &CreateGitIssueRequest{Title: title, Body: body, Assignee: assignee, Labels: labels}
 
-	newIssue, err := gh.CreateIssue("mot882000", "gitlab-test-project", inputIssue)
+	newIssue, err := gh.CreateIssue(context.Background(), "mot882000", "gitlab-test-project", inputIssue)
 	assert.NoError(err)
 	assert.Equal("test", newIssue.Title)
 	t.Log(newIssue.Body, newIssue.Title, newIssue.Labels, newIssue.Assignee)
 
-	issueList, err := gh.GetIssueList("mot882000", "gitlab-test-project")
+	issueList, err := gh.GetIssueList(context.Background(), "mot882000", "gitlab-test-project")
 	assert.NoError(err)
 
 	for _, v := range issueList {
@@ -1,5 +1,40 @@
 package domain
 
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// k8s cluster client interface
+//
+// ctx 가 취소되면 진행 중인 api 호출도 중단, 호출별 제한 시간은 cfg.Timeouts.K8s
+type K8sClientHandler interface {
+	GetServerVersion(ctx context.Context) (string, error)
+	GetPodList(ctx context.Context, namespace string) ([]*PodInfo, error)
+	GetPodEvent(ctx context.Context, namespace, podName string) ([]*PodEvent, error)
+	GetPodLogs(ctx context.Context, namespace, podName string, previous bool) (*string, error)
+	// stream 은 ctx 가 유지되는 동안 열려있으므로 제한 시간 미적용
+	StreamPodLogs(ctx context.Context, namespace, podName string, opts *PodLogStreamOptions) (io.ReadCloser, error)
+	GetPodDesc(ctx context.Context, namespace, podName string) (*PodDescription, error)
+	GetWorkloadList(ctx context.Context, kind WorkloadKind, namespace string) ([]*WorkloadInfo, error)
+	GetWorkload(ctx context.Context, kind WorkloadKind, namespace, name string) (*WorkloadDetail, error)
+	ScaleWorkload(ctx context.Context, kind WorkloadKind, namespace, name string, replicas int32) error
+	RestartWorkload(ctx context.Context, kind WorkloadKind, namespace, name string) error
+	RollbackDeployment(ctx context.Context, namespace, name string, revision int64) error
+	SetDeploymentPaused(ctx context.Context, namespace, name string, paused bool) error
+	// timeout 까지 상태 확인 반복, 확인 요청 1건마다 제한 시간 적용
+	GetRolloutStatus(ctx context.Context, kind WorkloadKind, namespace, name string, timeout time.Duration) (*RolloutStatus, error)
+}
+
+type ClusterStatus struct {
+	Name      string `json:"name"`
+	Default   bool   `json:"default"`
+	Reachable bool   `json:"reachable"`
+	Version   string `json:"version,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
 type PodInfo struct {
 	Name         string `json:"name"`
 	CurrentCount int    `json:"currentCount"`
@@ -10,3 +45,213 @@ type PodInfo struct {
 	NodeName     string `json:"nodeName"`
 	Age          string `json:"age"`
 }
+
+type PodEvent struct {
+	Type      string `json:"type"`
+	Reason    string `json:"reason"`
+	Message   string `json:"message"`
+	Source    string `json:"source"`
+	Count     int32  `json:"count"`
+	FirstSeen string `json:"firstSeen"`
+	LastSeen  string `json:"lastSeen"`
+}
+
+// kubectl resource 이름(복수형)과 동일
+type WorkloadKind string
+
+const (
+	Deployments  WorkloadKind = "deployments"
+	ReplicaSets  WorkloadKind = "replicasets"
+	StatefulSets WorkloadKind = "statefulsets"
+	DaemonSets   WorkloadKind = "daemonsets"
+	Jobs         WorkloadKind = "jobs"
+	CronJobs     WorkloadKind = "cronjobs"
+)
+
+// replica 수는 kind 별로 아래 값을 사용
+//
+// deployment/replicaset/statefulset: spec.replicas, daemonset: desiredNumberScheduled, job: completions
+type WorkloadInfo struct {
+	Kind       string               `json:"kind"`
+	Name       string               `json:"name"`
+	Namespace  string               `json:"namespace"`
+	Desired    int32                `json:"desired"`
+	Ready      int32                `json:"ready"`
+	Updated    int32                `json:"updated"`
+	Available  int32                `json:"available"`
+	Images     []string             `json:"images"`
+	Age        string               `json:"age"`
+	Selector   string               `json:"selector,omitempty"`
+	Conditions []*WorkloadCondition `json:"conditions,omitempty"`
+
+	// job, cronjob 전용
+	Active           int32  `json:"active,omitempty"`
+	Succeeded        int32  `json:"succeeded,omitempty"`
+	Failed           int32  `json:"failed,omitempty"`
+	Schedule         string `json:"schedule,omitempty"`
+	Suspend          bool   `json:"suspend,omitempty"`
+	LastScheduleTime string `json:"lastScheduleTime,omitempty"`
+}
+
+type WorkloadCondition struct {
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// Selector 로 조회한 pod 목록 포함
+type WorkloadDetail struct {
+	WorkloadInfo
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	Pods        []*PodInfo        `json:"pods"`
+}
+
+type ScaleWorkloadRequest struct {
+	Replicas int32 `json:"replicas"`
+}
+
+// revision 이 0 이면 직전 revision 으로 rollback
+type RollbackDeploymentRequest struct {
+	Revision int64 `json:"revision"`
+}
+
+type RolloutStatus struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Complete  bool   `json:"complete"`
+	Failed    bool   `json:"failed"`
+	Message   string `json:"message"`
+	Desired   int32  `json:"desired"`
+	Updated   int32  `json:"updated"`
+	Ready     int32  `json:"ready"`
+	Available int32  `json:"available"`
+}
+
+type PodLogStreamOptions struct {
+	Container    string
+	Follow       bool
+	Previous     bool
+	SinceSeconds *int64
+	TailLines    *int64
+	Timestamps   bool
+}
+
+// kubectl describe pod 와 같은 정보를 json 으로 제공
+type PodDescription struct {
+	Name              string                  `json:"name"`
+	Namespace         string                  `json:"namespace"`
+	Priority          *int32                  `json:"priority,omitempty"`
+	PriorityClassName string                  `json:"priorityClassName,omitempty"`
+	ServiceAccount    string                  `json:"serviceAccount,omitempty"`
+	Node              string                  `json:"node,omitempty"`
+	StartTime         string                  `json:"startTime,omitempty"`
+	Labels            map[string]string       `json:"labels,omitempty"`
+	Annotations       map[string]string       `json:"annotations,omitempty"`
+	Status            string                  `json:"status"`
+	Reason            string                  `json:"reason,omitempty"`
+	Message           string                  `json:"message,omitempty"`
+	IP                string                  `json:"ip,omitempty"`
+	IPs               []string                `json:"ips,omitempty"`
+	QOSClass          string                  `json:"qosClass,omitempty"`
+	OwnerReferences   []*OwnerReference       `json:"ownerReferences,omitempty"`
+	InitContainers    []*ContainerDescription `json:"initContainers,omitempty"`
+	Containers        []*ContainerDescription `json:"containers"`
+	Conditions        []*PodCondition         `json:"conditions,omitempty"`
+	Volumes           []*VolumeDescription    `json:"volumes,omitempty"`
+	NodeSelector      map[string]string       `json:"nodeSelector,omitempty"`
+	Tolerations       []*Toleration           `json:"tolerations,omitempty"`
+}
+
+type OwnerReference struct {
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	Controller bool   `json:"controller"`
+}
+
+type ContainerDescription struct {
+	Name           string            `json:"name"`
+	ContainerID    string            `json:"containerID,omitempty"`
+	Image          string            `json:"image"`
+	ImageID        string            `json:"imageID,omitempty"`
+	Ports          []*ContainerPort  `json:"ports,omitempty"`
+	Command        []string          `json:"command,omitempty"`
+	Args           []string          `json:"args,omitempty"`
+	State          *ContainerState   `json:"state,omitempty"`
+	LastState      *ContainerState   `json:"lastState,omitempty"`
+	Ready          bool              `json:"ready"`
+	RestartCount   int32             `json:"restartCount"`
+	Limits         map[string]string `json:"limits,omitempty"`
+	Requests       map[string]string `json:"requests,omitempty"`
+	LivenessProbe  *Probe            `json:"livenessProbe,omitempty"`
+	ReadinessProbe *Probe            `json:"readinessProbe,omitempty"`
+	StartupProbe   *Probe            `json:"startupProbe,omitempty"`
+	Env            []*EnvVar         `json:"env,omitempty"`
+	VolumeMounts   []*VolumeMount    `json:"volumeMounts,omitempty"`
+}
+
+type ContainerPort struct {
+	Name          string `json:"name,omitempty"`
+	ContainerPort int32  `json:"containerPort"`
+	HostPort      int32  `json:"hostPort,omitempty"`
+	Protocol      string `json:"protocol"`
+}
+
+// State 는 Running, Waiting, Terminated 중 하나
+type ContainerState struct {
+	State      string `json:"state"`
+	Reason     string `json:"reason,omitempty"`
+	Message    string `json:"message,omitempty"`
+	ExitCode   *int32 `json:"exitCode,omitempty"`
+	StartedAt  string `json:"startedAt,omitempty"`
+	FinishedAt string `json:"finishedAt,omitempty"`
+}
+
+// Type 은 http-get, tcp-socket, exec, grpc 중 하나
+type Probe struct {
+	Type                string `json:"type"`
+	Target              string `json:"target"`
+	InitialDelaySeconds int32  `json:"initialDelaySeconds"`
+	TimeoutSeconds      int32  `json:"timeoutSeconds"`
+	PeriodSeconds       int32  `json:"periodSeconds"`
+	SuccessThreshold    int32  `json:"successThreshold"`
+	FailureThreshold    int32  `json:"failureThreshold"`
+}
+
+// secret/configmap 참조는 값 대신 From 에 참조 대상을 표시
+type EnvVar struct {
+	Name  string `json:"name"`
+	Value string `json:"value,omitempty"`
+	From  string `json:"from,omitempty"`
+}
+
+type VolumeMount struct {
+	Name      string `json:"name"`
+	MountPath string `json:"mountPath"`
+	SubPath   string `json:"subPath,omitempty"`
+	ReadOnly  bool   `json:"readOnly"`
+}
+
+type PodCondition struct {
+	Type               string `json:"type"`
+	Status             string `json:"status"`
+	Reason             string `json:"reason,omitempty"`
+	Message            string `json:"message,omitempty"`
+	LastTransitionTime string `json:"lastTransitionTime,omitempty"`
+}
+
+// Type 은 ConfigMap, Secret, EmptyDir 등 volume source 종류
+type VolumeDescription struct {
+	Name   string            `json:"name"`
+	Type   string            `json:"type"`
+	Source map[string]string `json:"source,omitempty"`
+}
+
+type Toleration struct {
+	Key               string `json:"key,omitempty"`
+	Operator          string `json:"operator,omitempty"`
+	Value             string `json:"value,omitempty"`
+	Effect            string `json:"effect,omitempty"`
+	TolerationSeconds *int64 `json:"tolerationSeconds,omitempty"`
+}
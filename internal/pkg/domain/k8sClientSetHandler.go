@@ -1,41 +1,94 @@
 package domain
 
 import (
-	"backend/config"
-	"bytes"
+	"backend/internal/pkg/ctxutil"
 	"context"
-	"fmt"
+	"encoding/json"
 	"io"
 	"log"
-	"reflect"
-	"strconv"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/version"
 
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/clientcmd"
 )
 
 type K8sClientSetHandler struct {
-	clientSet *kubernetes.Clientset
+	clientSet kubernetes.Interface
+	timeout   time.Duration
 }
 
-func NewK8sClientSetHandler(cfg config.Config) *K8sClientSetHandler {
-	clientConfig, _ := clientcmd.NewClientConfigFromBytes([]byte(cfg.ClusterToken))
-	restConfig, _ := clientConfig.ClientConfig()
+// kubeconfig 내용으로 clientset 생성, api 호출 1건마다 timeout 적용 (0 이면 제한 없음)
+func NewK8sClientSetHandler(kubeConfig []byte, timeout time.Duration) (K8sClientHandler, error) {
+	clientConfig, err := clientcmd.NewClientConfigFromBytes(kubeConfig)
+	if err != nil {
+		log.Printf("NewClientConfigFromBytes returned error: %v", err)
+		return nil, err
+	}
+	restConfig, err := clientConfig.ClientConfig()
+	if err != nil {
+		log.Printf("ClientConfig returned error: %v", err)
+		return nil, err
+	}
 	clientSet, err := kubernetes.NewForConfig(restConfig)
 	if err != nil {
 		log.Printf("NewForConfig returned error: %v", err)
-		return nil
+		return nil, err
 	}
 	return &K8sClientSetHandler{
 		clientSet: clientSet,
+		timeout:   timeout,
+	}, nil
+}
+
+// test 시 client-go fake clientset 주입용
+func NewK8sClientSetHandlerWithClientSet(clientSet kubernetes.Interface) K8sClientHandler {
+	return &K8sClientSetHandler{
+		clientSet: clientSet,
+	}
+}
+
+func (k *K8sClientSetHandler) GetServerVersion(ctx context.Context) (string, error) {
+	ctx, cancel := ctxutil.WithTimeout(ctx, k.timeout)
+	defer cancel()
+
+	info, err := k.serverVersion(ctx)
+	if err != nil {
+		log.Printf("GetServerVersion returned error: %v", err)
+		return "", err
+	}
+	return info.GitVersion, nil
+}
+
+// discovery client 의 ServerVersion 은 ctx 를 받지 않으므로 /version 을 직접 요청
+//
+// fake clientset 은 rest client 가 없어 ServerVersion 사용
+func (k *K8sClientSetHandler) serverVersion(ctx context.Context) (*version.Info, error) {
+	restClient := k.clientSet.Discovery().RESTClient()
+	if restClient == nil {
+		return k.clientSet.Discovery().ServerVersion()
+	}
+
+	body, err := restClient.Get().AbsPath("/version").Do(ctx).Raw()
+	if err != nil {
+		return nil, err
+	}
+
+	info := &version.Info{}
+	if err := json.Unmarshal(body, info); err != nil {
+		return nil, err
 	}
+	return info, nil
 }
 
-func (k *K8sClientSetHandler) GetPodList(namespace, cluster string) ([]*PodInfo, error) {
-	pods, err := k.clientSet.CoreV1().Pods(namespace).List(context.TODO(), metav1.ListOptions{})
+func (k *K8sClientSetHandler) GetPodList(ctx context.Context, namespace string) ([]*PodInfo, error) {
+	ctx, cancel := ctxutil.WithTimeout(ctx, k.timeout)
+	defer cancel()
+
+	pods, err := k.clientSet.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
 	if err != nil {
 		log.Printf("GetPodList returned error: %v", err)
 		return nil, err
@@ -67,22 +120,31 @@ func createReturnPodList(pods *v1.PodList) []*PodInfo {
 			RestartCount: restartCount,
 			PodIP:        v.Status.PodIP,
 			NodeName:     v.Spec.NodeName,
-			Age:          v.Status.StartTime.Time.String(),
+			Age:          getStartTime(v.Status.StartTime),
 		}
 	}
 	return returnPodList
 }
 
+// pending 상태의 pod 는 StartTime 이 없음
+func getStartTime(startTime *metav1.Time) string {
+	if startTime == nil {
+		return ""
+	}
+	return startTime.Time.String()
+}
+
 func isContainerStarted(containerStatus v1.ContainerStatus) bool {
-	return *containerStatus.Started
+	return containerStatus.Started != nil && *containerStatus.Started
 }
 
 func getContainerStatus(containerStatus v1.ContainerStatus) string {
 	if containerStatus.State.Waiting != nil {
 		return containerStatus.State.Waiting.Reason
-	} else {
+	} else if containerStatus.State.Terminated != nil {
 		return containerStatus.State.Terminated.Reason
 	}
+	return "Unknown"
 }
 
 func getRestartCount(containerStatuses []v1.ContainerStatus) int32 {
@@ -93,187 +155,97 @@ func getRestartCount(containerStatuses []v1.ContainerStatus) int32 {
 	return restartCount
 }
 
-func (k *K8sClientSetHandler) GetPodEvent(namespace, podName string) (*v1.EventList, error) {
-	events, err := k.clientSet.CoreV1().Events(namespace).List(context.TODO(), metav1.ListOptions{FieldSelector: "involvedObject.name=" + podName, TypeMeta: metav1.TypeMeta{Kind: "Pod"}})
+func (k *K8sClientSetHandler) GetPodEvent(ctx context.Context, namespace, podName string) ([]*PodEvent, error) {
+	ctx, cancel := ctxutil.WithTimeout(ctx, k.timeout)
+	defer cancel()
+
+	events, err := k.clientSet.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{FieldSelector: "involvedObject.name=" + podName, TypeMeta: metav1.TypeMeta{Kind: "Pod"}})
 
 	if err != nil {
 		log.Printf("GetPodEvent returned error: %v", err)
 		return nil, err
 	}
 
-	return events, nil
+	returnEventList := make([]*PodEvent, len(events.Items))
+
+	for i, v := range events.Items {
+		returnEventList[i] = &PodEvent{
+			Type:      v.Type,
+			Reason:    v.Reason,
+			Message:   v.Message,
+			Source:    v.Source.Component,
+			Count:     v.Count,
+			FirstSeen: v.FirstTimestamp.String(),
+			LastSeen:  v.LastTimestamp.String(),
+		}
+	}
+
+	return returnEventList, nil
 }
 
 // 500 row
-func (k *K8sClientSetHandler) GetPodLogs(namespace, podName string, previous bool) (*string, error) {
+func (k *K8sClientSetHandler) GetPodLogs(ctx context.Context, namespace, podName string, previous bool) (*string, error) {
+	ctx, cancel := ctxutil.WithTimeout(ctx, k.timeout)
+	defer cancel()
 
 	count := int64(500)
 	podLogOptions := v1.PodLogOptions{
-		//Follow:    true,
 		TailLines: &count,
 		Previous:  previous,
 	}
 
 	podLogRequest := k.clientSet.CoreV1().Pods(namespace).GetLogs(podName, &podLogOptions)
 
-	stream, err := podLogRequest.Stream(context.TODO())
+	stream, err := podLogRequest.Stream(ctx)
 	if err != nil {
 		log.Printf("GetPodLogs returned error: %v", err)
 		return nil, err
 	}
 	defer stream.Close()
 
-	logs := ""
-
-	for {
-		buf := make([]byte, 2000)
-		numBytes, err := stream.Read(buf)
-		if numBytes == 0 {
-			break
-		}
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			log.Printf("GetPodLogs stream.Read returned error: %v", err)
-			return nil, err
-		}
-
-		message := string(buf[:numBytes])
-		logs += message
-	}
-
-	return &logs, nil
-}
-
-func (k *K8sClientSetHandler) GetPodDesc(namespace, podName string) (string, error) {
-
-	result, err := k.clientSet.CoreV1().Pods(namespace).Get(context.TODO(), podName, metav1.GetOptions{})
+	buf, err := io.ReadAll(stream)
 	if err != nil {
-		log.Printf("GetPodDesc returned error: %v", err)
-		return "", err
+		log.Printf("GetPodLogs stream.Read returned error: %v", err)
+		return nil, err
 	}
 
-	var podDesc bytes.Buffer
-
-	podDesc.WriteString("Name : " + result.GetObjectMeta().GetName() + "\n")
-	podDesc.WriteString("Namespace: " + result.GetObjectMeta().GetNamespace() + "\n")
-	//podDesc.WriteString( "Priority: " + &result.Spec.Priority
-	podDesc.WriteString("Node: " + result.Spec.NodeName + "\n")
-	podDesc.WriteString("Start Time: " + result.Status.StartTime.String() + "\n")
-
-	getLabels(&podDesc, result.GetObjectMeta().GetLabels())
-	getAnnotations(&podDesc, result.ObjectMeta.GetAnnotations())
-
-	podDesc.WriteString("Status: " + string(result.Status.Phase) + "\n")
-	podDesc.WriteString("IP: " + result.Status.PodIP + "\n")
+	logs := string(buf)
 
-	getContainers(&podDesc, result.Status.ContainerStatuses)
-	getContainerSpec(&podDesc, result.Spec.Containers)
-	getVolumes(&podDesc, result.Spec.Volumes)
-	getPodStatus(&podDesc, result.Status.Conditions)
-	return podDesc.String(), nil
+	return &logs, nil
 }
 
-func getLabels(podDesc *bytes.Buffer, labelMap map[string]string) {
-	podDesc.WriteString("Labels: " + "\n")
-	for key, v := range labelMap {
-		podDesc.WriteString("             " + key + "=" + v + "\n")
-	}
-}
+// follow 옵션 사용 시 ctx 가 취소될 때까지 stream 유지
+//
+// 반환된 stream 은 호출자가 Close 해야 함
+func (k *K8sClientSetHandler) StreamPodLogs(ctx context.Context, namespace, podName string, opts *PodLogStreamOptions) (io.ReadCloser, error) {
 
-func getAnnotations(podDesc *bytes.Buffer, annotationMap map[string]string) {
-	podDesc.WriteString("Annotations: " + "\n")
-	for key, v := range annotationMap {
-		podDesc.WriteString("             " + key + ": " + v + "\n")
+	podLogOptions := v1.PodLogOptions{
+		Container:    opts.Container,
+		Follow:       opts.Follow,
+		Previous:     opts.Previous,
+		SinceSeconds: opts.SinceSeconds,
+		TailLines:    opts.TailLines,
+		Timestamps:   opts.Timestamps,
 	}
-}
-
-func getContainers(podDesc *bytes.Buffer, containerStatuses []v1.ContainerStatus) {
-	podDesc.WriteString("Containers: " + "\n")
-	for _, v := range containerStatuses {
-		podDesc.WriteString("  " + v.Name + "\n")
-		podDesc.WriteString("    Container ID: " + v.ContainerID + "\n")
-		podDesc.WriteString("    Image: " + v.Image + "\n")
-		podDesc.WriteString("    Image ID: " + v.ImageID + "\n")
-
-		if *v.Started {
-			podDesc.WriteString("    State: Running" + "\n")
-			podDesc.WriteString("      Started: " + v.State.Running.StartedAt.String() + "\n")
-		} else {
-			if v.State.Waiting != nil {
-				podDesc.WriteString("    State: " + v.State.Waiting.Reason + "\n")
-			} else {
-				podDesc.WriteString("    State: " + v.State.Terminated.Reason + "\n")
-			}
-		}
-		podDesc.WriteString("    Ready: " + strconv.FormatBool(v.Ready) + "\n")
-		podDesc.WriteString("    Restart Count: " + strconv.Itoa(int(v.RestartCount)) + "\n")
 
+	stream, err := k.clientSet.CoreV1().Pods(namespace).GetLogs(podName, &podLogOptions).Stream(ctx)
+	if err != nil {
+		log.Printf("StreamPodLogs returned error: %v", err)
+		return nil, err
 	}
-}
-
-func getContainerSpec(podDesc *bytes.Buffer, containers []v1.Container) {
-	for _, v := range containers {
-		podDesc.WriteString("  " + v.Name + "\n")
-		//podDesc.WriteString( "    Image: " + val.Image + "\n")
-		for _, portVal := range v.Ports {
-			podDesc.WriteString("    Port: " + strconv.Itoa(int(portVal.ContainerPort)) + "/" + string(portVal.Protocol) + "\n")
-			podDesc.WriteString("    Host Port: " + strconv.Itoa(int(portVal.HostPort)) + "/" + string(portVal.Protocol) + "\n")
-
-		}
 
-		podDesc.WriteString("    Limits: " + "\n")
-		podDesc.WriteString("      cpu: " + v.Resources.Limits.Cpu().String() + "\n")
-		podDesc.WriteString("      memory: " + v.Resources.Limits.Memory().String() + "\n")
-
-		podDesc.WriteString("    Requests: " + "\n")
-		podDesc.WriteString("      cpu: " + v.Resources.Requests.Cpu().String() + "\n")
-		podDesc.WriteString("      memory: " + v.Resources.Requests.Memory().String() + "\n")
-
-		podDesc.WriteString("    VolumeMounts: " + "\n")
-		for _, volumeMountsVal := range v.VolumeMounts {
-			podDesc.WriteString("      " + volumeMountsVal.Name + "\n")
-			podDesc.WriteString("        ReadOnly: " + strconv.FormatBool(volumeMountsVal.ReadOnly) + "\n")
-			podDesc.WriteString("        MountPath: " + volumeMountsVal.MountPath + "\n")
-			podDesc.WriteString("        SubPath: " + volumeMountsVal.SubPath + "\n")
-		}
-	}
+	return stream, nil
 }
 
-func getVolumes(podDesc *bytes.Buffer, volumes []v1.Volume) {
-
-	podDesc.WriteString("Volumes: " + "\n")
-	for _, v := range volumes {
-		podDesc.WriteString("  " + v.Name + "\n")
-
-		v := reflect.ValueOf(v.VolumeSource)
-		typeOfField := v.Type()
-
-		for i := 0; i < v.NumField(); i++ {
-			if !v.Field(i).IsNil() {
-				podDesc.WriteString("    type: " + typeOfField.Field(i).Name + "\n")
-				podDesc.WriteString("    name: " + fmt.Sprintf("%v", v.Field(i).Interface()) + "\n")
-			}
-		}
+func (k *K8sClientSetHandler) GetPodDesc(ctx context.Context, namespace, podName string) (*PodDescription, error) {
+	ctx, cancel := ctxutil.WithTimeout(ctx, k.timeout)
+	defer cancel()
 
+	result, err := k.clientSet.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		log.Printf("GetPodDesc returned error: %v", err)
+		return nil, err
 	}
-}
 
-func getPodStatus(podDesc *bytes.Buffer, podConditions []v1.PodCondition) {
-	podDesc.WriteString("PodStatus: " + "\n")
-	for i, v := range podConditions {
-		podDesc.WriteString("  [" + strconv.Itoa(i) + "]\n")
-		podDesc.WriteString("  Type: " + string(v.Type) + "\n")
-		podDesc.WriteString("  Type: " + string(v.Type) + "\n")
-		podDesc.WriteString("  Status: " + string(v.Status) + "\n")
-		podDesc.WriteString("  [" + strconv.Itoa(i) + "]\n")
-		podDesc.WriteString("  Type: " + string(v.Type) + "\n")
-		podDesc.WriteString("  Status: " + string(v.Status) + "\n")
-		podDesc.WriteString("  LastProbeTime: " + v.LastProbeTime.String() + "\n")
-		podDesc.WriteString("  LastTransitionTime: " + v.LastTransitionTime.String() + "\n")
-		podDesc.WriteString("  Reason: " + string(v.Reason) + "\n")
-		podDesc.WriteString("  Messae: " + string(v.Message) + "\n")
-
-	}
+	return createPodDescription(result), nil
 }
@@ -0,0 +1,199 @@
+package domain
+
+import (
+	"backend/config"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrClusterNotFound = errors.New("k8s cluster not found")
+
+// 연결 확인 시 cluster 당 최대 대기 시간
+const clusterCheckTimeout = 5 * time.Second
+
+// config.toml 의 clusters 목록을 이름으로 관리
+//
+// clientset 은 해당 cluster 를 처음 사용할 때 생성
+type K8sClusterRegistry struct {
+	mu             sync.Mutex
+	names          []string
+	defaultCluster string
+	clusters       map[string]config.Cluster
+	clients        map[string]K8sClientHandler
+	timeout        time.Duration
+}
+
+func NewK8sClusterRegistry(cfg config.Config) *K8sClusterRegistry {
+	r := &K8sClusterRegistry{
+		clusters: make(map[string]config.Cluster),
+		clients:  make(map[string]K8sClientHandler),
+		timeout:  cfg.Timeouts.K8s,
+	}
+
+	for _, v := range cfg.Clusters {
+		if v.Name == "" {
+			log.Printf("k8s cluster without name is ignored")
+			continue
+		}
+		if _, ok := r.clusters[v.Name]; ok {
+			log.Printf("duplicated k8s cluster %q is ignored", v.Name)
+			continue
+		}
+		r.clusters[v.Name] = v
+		r.names = append(r.names, v.Name)
+	}
+
+	// 기존 clusterToken 설정 호환
+	if _, ok := r.clusters["default"]; !ok && cfg.ClusterToken != "" {
+		r.clusters["default"] = config.Cluster{Name: "default", KubeConfig: cfg.ClusterToken}
+		r.names = append(r.names, "default")
+	}
+
+	r.defaultCluster = cfg.DefaultCluster
+	if _, ok := r.clusters[r.defaultCluster]; !ok {
+		if r.defaultCluster != "" {
+			log.Printf("default k8s cluster %q is not configured", r.defaultCluster)
+		}
+		r.defaultCluster = ""
+		if len(r.names) > 0 {
+			r.defaultCluster = r.names[0]
+		}
+	}
+
+	return r
+}
+
+// test 시 fake clientset 기반 client 주입용
+func NewK8sClusterRegistryWithClients(defaultCluster string, clients map[string]K8sClientHandler) *K8sClusterRegistry {
+	r := &K8sClusterRegistry{
+		defaultCluster: defaultCluster,
+		clusters:       make(map[string]config.Cluster),
+		clients:        make(map[string]K8sClientHandler),
+	}
+
+	for name, client := range clients {
+		r.clusters[name] = config.Cluster{Name: name}
+		r.clients[name] = client
+		r.names = append(r.names, name)
+	}
+	sort.Strings(r.names)
+
+	return r
+}
+
+// 등록된 cluster 이름 목록 (config 순서)
+func (r *K8sClusterRegistry) Names() []string {
+	return append([]string(nil), r.names...)
+}
+
+func (r *K8sClusterRegistry) DefaultCluster() string {
+	return r.defaultCluster
+}
+
+// name 이 비어있으면 default cluster 반환
+func (r *K8sClusterRegistry) Client(name string) (K8sClientHandler, error) {
+	if name == "" {
+		name = r.defaultCluster
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if client, ok := r.clients[name]; ok {
+		return client, nil
+	}
+
+	cluster, ok := r.clusters[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrClusterNotFound, name)
+	}
+
+	kubeConfig, err := loadKubeConfig(cluster)
+	if err != nil {
+		log.Printf("loadKubeConfig(%s) returned error: %v", name, err)
+		return nil, err
+	}
+
+	client, err := NewK8sClientSetHandler(kubeConfig, r.timeout)
+	if err != nil {
+		return nil, fmt.Errorf("k8s cluster %q: %w", name, err)
+	}
+
+	r.clients[name] = client
+
+	return client, nil
+}
+
+func loadKubeConfig(cluster config.Cluster) ([]byte, error) {
+	if cluster.KubeConfig != "" {
+		return []byte(cluster.KubeConfig), nil
+	}
+	if cluster.KubeConfigPath == "" {
+		return nil, fmt.Errorf("k8s cluster %q has neither kubeconfig nor kubeconfigPath", cluster.Name)
+	}
+
+	path := cluster.KubeConfigPath
+	if strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		path = filepath.Join(home, path[2:])
+	}
+
+	return os.ReadFile(path)
+}
+
+// 모든 cluster 에 server version 을 요청하여 연결 상태 확인
+func (r *K8sClusterRegistry) CheckClusters(ctx context.Context) []*ClusterStatus {
+	statuses := make([]*ClusterStatus, len(r.names))
+
+	var wg sync.WaitGroup
+	for i, name := range r.names {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			statuses[i] = r.checkCluster(ctx, name)
+		}(i, name)
+	}
+	wg.Wait()
+
+	return statuses
+}
+
+func (r *K8sClusterRegistry) checkCluster(ctx context.Context, name string) *ClusterStatus {
+	status := &ClusterStatus{
+		Name:    name,
+		Default: name == r.defaultCluster,
+	}
+
+	client, err := r.Client(name)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, clusterCheckTimeout)
+	defer cancel()
+
+	version, err := client.GetServerVersion(ctx)
+	if err != nil {
+		status.Error = err.Error()
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			status.Error = "timeout"
+		}
+		return status
+	}
+	status.Reachable = true
+	status.Version = version
+
+	return status
+}
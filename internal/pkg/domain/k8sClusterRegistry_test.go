@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"backend/config"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// server version 만 응답하는 api server
+func newVersionServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/version" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"major":"1","minor":"26","gitVersion":"v1.26.2"}`)
+	}))
+}
+
+func kubeConfigFor(server string) string {
+	return fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: %s
+contexts:
+- name: test
+  context:
+    cluster: test
+    user: test
+current-context: test
+users:
+- name: test
+  user:
+    token: token
+`, server)
+}
+
+func TestK8sClusterRegistry(t *testing.T) {
+	assert := assert.New(t)
+
+	server := newVersionServer()
+	defer server.Close()
+
+	kubeConfigPath := filepath.Join(t.TempDir(), "kubeconfig")
+	assert.NoError(os.WriteFile(kubeConfigPath, []byte(kubeConfigFor(server.URL)), 0600))
+
+	r := NewK8sClusterRegistry(config.Config{
+		DefaultCluster: "prod",
+		Clusters: []config.Cluster{
+			{Name: "dev", KubeConfig: kubeConfigFor(server.URL)},
+			{Name: "prod", KubeConfigPath: kubeConfigPath},
+			{Name: "dev", KubeConfig: "duplicated"},
+			{Name: "broken", KubeConfig: "not a kubeconfig"},
+			{Name: "empty"},
+		},
+	})
+
+	assert.Equal([]string{"dev", "prod", "broken", "empty"}, r.Names())
+	assert.Equal("prod", r.DefaultCluster())
+
+	// 처음 사용할 때 생성 후 재사용
+	client, err := r.Client("")
+	assert.NoError(err)
+	again, err := r.Client("prod")
+	assert.NoError(err)
+	assert.Same(client, again)
+
+	_, err = r.Client("unknown")
+	assert.ErrorIs(err, ErrClusterNotFound)
+
+	_, err = r.Client("broken")
+	assert.Error(err)
+
+	statuses := r.CheckClusters(context.Background())
+	if assert.Equal(4, len(statuses)) {
+		assert.True(statuses[0].Reachable)
+		assert.Equal("v1.26.2", statuses[0].Version)
+		assert.True(statuses[1].Reachable)
+		assert.True(statuses[1].Default)
+		assert.False(statuses[2].Reachable)
+		assert.NotEmpty(statuses[2].Error)
+		assert.False(statuses[3].Reachable)
+		assert.NotEmpty(statuses[3].Error)
+	}
+}
+
+func TestK8sClusterRegistryClusterToken(t *testing.T) {
+	assert := assert.New(t)
+
+	// 기존 clusterToken 만 설정된 경우 default cluster 로 등록
+	r := NewK8sClusterRegistry(config.Config{ClusterToken: "token", DefaultCluster: "unknown"})
+	assert.Equal([]string{"default"}, r.Names())
+	assert.Equal("default", r.DefaultCluster())
+
+	// cluster 가 없는 경우
+	r = NewK8sClusterRegistry(config.Config{})
+	assert.Empty(r.Names())
+	_, err := r.Client("")
+	assert.ErrorIs(err, ErrClusterNotFound)
+}
@@ -0,0 +1,591 @@
+package domain
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func createPodDescription(pod *v1.Pod) *PodDescription {
+	desc := &PodDescription{
+		Name:              pod.Name,
+		Namespace:         pod.Namespace,
+		Priority:          pod.Spec.Priority,
+		PriorityClassName: pod.Spec.PriorityClassName,
+		ServiceAccount:    pod.Spec.ServiceAccountName,
+		Node:              pod.Spec.NodeName,
+		Labels:            pod.Labels,
+		Annotations:       pod.Annotations,
+		Status:            string(pod.Status.Phase),
+		Reason:            pod.Status.Reason,
+		Message:           pod.Status.Message,
+		IP:                pod.Status.PodIP,
+		QOSClass:          string(pod.Status.QOSClass),
+		NodeSelector:      pod.Spec.NodeSelector,
+	}
+
+	if pod.Status.StartTime != nil {
+		desc.StartTime = formatTime(*pod.Status.StartTime)
+	}
+
+	for _, v := range pod.Status.PodIPs {
+		desc.IPs = append(desc.IPs, v.IP)
+	}
+
+	for _, v := range pod.OwnerReferences {
+		desc.OwnerReferences = append(desc.OwnerReferences, &OwnerReference{
+			Kind:       v.Kind,
+			Name:       v.Name,
+			Controller: v.Controller != nil && *v.Controller,
+		})
+	}
+
+	desc.InitContainers = createContainerDescriptions(pod.Spec.InitContainers, pod.Status.InitContainerStatuses)
+	desc.Containers = createContainerDescriptions(pod.Spec.Containers, pod.Status.ContainerStatuses)
+
+	for _, v := range pod.Status.Conditions {
+		condition := &PodCondition{
+			Type:               string(v.Type),
+			Status:             string(v.Status),
+			Reason:             v.Reason,
+			Message:            v.Message,
+			LastTransitionTime: formatTime(v.LastTransitionTime),
+		}
+		desc.Conditions = append(desc.Conditions, condition)
+	}
+
+	for _, v := range pod.Spec.Volumes {
+		desc.Volumes = append(desc.Volumes, createVolumeDescription(v))
+	}
+
+	for _, v := range pod.Spec.Tolerations {
+		desc.Tolerations = append(desc.Tolerations, &Toleration{
+			Key:               v.Key,
+			Operator:          string(v.Operator),
+			Value:             v.Value,
+			Effect:            string(v.Effect),
+			TolerationSeconds: v.TolerationSeconds,
+		})
+	}
+
+	return desc
+}
+
+// 값이 없으면 빈 문자열
+func formatTime(t metav1.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Time.Format(time.RFC3339)
+}
+
+// spec 과 status 를 container 이름으로 연결
+func createContainerDescriptions(containers []v1.Container, statuses []v1.ContainerStatus) []*ContainerDescription {
+	if len(containers) == 0 {
+		return nil
+	}
+
+	statusMap := make(map[string]v1.ContainerStatus, len(statuses))
+	for _, v := range statuses {
+		statusMap[v.Name] = v
+	}
+
+	descriptions := make([]*ContainerDescription, len(containers))
+
+	for i, v := range containers {
+		container := &ContainerDescription{
+			Name:           v.Name,
+			Image:          v.Image,
+			Command:        v.Command,
+			Args:           v.Args,
+			Limits:         resourceListToMap(v.Resources.Limits),
+			Requests:       resourceListToMap(v.Resources.Requests),
+			LivenessProbe:  createProbe(v.LivenessProbe),
+			ReadinessProbe: createProbe(v.ReadinessProbe),
+			StartupProbe:   createProbe(v.StartupProbe),
+		}
+
+		for _, port := range v.Ports {
+			container.Ports = append(container.Ports, &ContainerPort{
+				Name:          port.Name,
+				ContainerPort: port.ContainerPort,
+				HostPort:      port.HostPort,
+				Protocol:      string(port.Protocol),
+			})
+		}
+
+		for _, env := range v.Env {
+			container.Env = append(container.Env, createEnvVar(env))
+		}
+
+		for _, mount := range v.VolumeMounts {
+			container.VolumeMounts = append(container.VolumeMounts, &VolumeMount{
+				Name:      mount.Name,
+				MountPath: mount.MountPath,
+				SubPath:   mount.SubPath,
+				ReadOnly:  mount.ReadOnly,
+			})
+		}
+
+		if status, ok := statusMap[v.Name]; ok {
+			container.ContainerID = status.ContainerID
+			container.ImageID = status.ImageID
+			container.Ready = status.Ready
+			container.RestartCount = status.RestartCount
+			container.State = createContainerState(status.State)
+			container.LastState = createContainerState(status.LastTerminationState)
+		}
+
+		descriptions[i] = container
+	}
+
+	return descriptions
+}
+
+func resourceListToMap(resources v1.ResourceList) map[string]string {
+	if len(resources) == 0 {
+		return nil
+	}
+	m := make(map[string]string, len(resources))
+	for name, quantity := range resources {
+		m[string(name)] = quantity.String()
+	}
+	return m
+}
+
+// 상태 정보가 없으면 nil 반환
+func createContainerState(state v1.ContainerState) *ContainerState {
+	if state.Running != nil {
+		return &ContainerState{
+			State:     "Running",
+			StartedAt: formatTime(state.Running.StartedAt),
+		}
+	}
+	if state.Waiting != nil {
+		return &ContainerState{
+			State:   "Waiting",
+			Reason:  state.Waiting.Reason,
+			Message: state.Waiting.Message,
+		}
+	}
+	if state.Terminated != nil {
+		exitCode := state.Terminated.ExitCode
+		return &ContainerState{
+			State:      "Terminated",
+			Reason:     state.Terminated.Reason,
+			Message:    state.Terminated.Message,
+			ExitCode:   &exitCode,
+			StartedAt:  formatTime(state.Terminated.StartedAt),
+			FinishedAt: formatTime(state.Terminated.FinishedAt),
+		}
+	}
+	return nil
+}
+
+func createProbe(probe *v1.Probe) *Probe {
+	if probe == nil {
+		return nil
+	}
+
+	p := &Probe{
+		InitialDelaySeconds: probe.InitialDelaySeconds,
+		TimeoutSeconds:      probe.TimeoutSeconds,
+		PeriodSeconds:       probe.PeriodSeconds,
+		SuccessThreshold:    probe.SuccessThreshold,
+		FailureThreshold:    probe.FailureThreshold,
+	}
+
+	switch {
+	case probe.HTTPGet != nil:
+		scheme := strings.ToLower(string(probe.HTTPGet.Scheme))
+		if scheme == "" {
+			scheme = "http"
+		}
+		p.Type = "http-get"
+		p.Target = fmt.Sprintf("%s://%s:%s%s", scheme, probe.HTTPGet.Host, probe.HTTPGet.Port.String(), probe.HTTPGet.Path)
+	case probe.TCPSocket != nil:
+		p.Type = "tcp-socket"
+		p.Target = fmt.Sprintf("%s:%s", probe.TCPSocket.Host, probe.TCPSocket.Port.String())
+	case probe.Exec != nil:
+		p.Type = "exec"
+		p.Target = strings.Join(probe.Exec.Command, " ")
+	case probe.GRPC != nil:
+		p.Type = "grpc"
+		p.Target = fmt.Sprintf(":%d", probe.GRPC.Port)
+		if probe.GRPC.Service != nil && *probe.GRPC.Service != "" {
+			p.Target += " " + *probe.GRPC.Service
+		}
+	default:
+		p.Type = "unknown"
+	}
+
+	return p
+}
+
+func createEnvVar(env v1.EnvVar) *EnvVar {
+	e := &EnvVar{Name: env.Name, Value: env.Value}
+
+	if from := env.ValueFrom; from != nil {
+		switch {
+		case from.SecretKeyRef != nil:
+			e.From = fmt.Sprintf("secret %s/%s", from.SecretKeyRef.Name, from.SecretKeyRef.Key)
+		case from.ConfigMapKeyRef != nil:
+			e.From = fmt.Sprintf("configmap %s/%s", from.ConfigMapKeyRef.Name, from.ConfigMapKeyRef.Key)
+		case from.FieldRef != nil:
+			e.From = "field " + from.FieldRef.FieldPath
+		case from.ResourceFieldRef != nil:
+			e.From = "resource " + from.ResourceFieldRef.Resource
+		}
+	}
+
+	return e
+}
+
+func createVolumeDescription(volume v1.Volume) *VolumeDescription {
+	desc := &VolumeDescription{Name: volume.Name, Source: map[string]string{}}
+	source := volume.VolumeSource
+
+	switch {
+	case source.ConfigMap != nil:
+		desc.Type = "ConfigMap"
+		desc.Source["name"] = source.ConfigMap.Name
+		desc.Source["optional"] = strconv.FormatBool(source.ConfigMap.Optional != nil && *source.ConfigMap.Optional)
+	case source.Secret != nil:
+		desc.Type = "Secret"
+		desc.Source["secretName"] = source.Secret.SecretName
+		desc.Source["optional"] = strconv.FormatBool(source.Secret.Optional != nil && *source.Secret.Optional)
+	case source.EmptyDir != nil:
+		desc.Type = "EmptyDir"
+		desc.Source["medium"] = string(source.EmptyDir.Medium)
+		if source.EmptyDir.SizeLimit != nil {
+			desc.Source["sizeLimit"] = source.EmptyDir.SizeLimit.String()
+		}
+	case source.HostPath != nil:
+		desc.Type = "HostPath"
+		desc.Source["path"] = source.HostPath.Path
+		if source.HostPath.Type != nil {
+			desc.Source["hostPathType"] = string(*source.HostPath.Type)
+		}
+	case source.PersistentVolumeClaim != nil:
+		desc.Type = "PersistentVolumeClaim"
+		desc.Source["claimName"] = source.PersistentVolumeClaim.ClaimName
+		desc.Source["readOnly"] = strconv.FormatBool(source.PersistentVolumeClaim.ReadOnly)
+	case source.NFS != nil:
+		desc.Type = "NFS"
+		desc.Source["server"] = source.NFS.Server
+		desc.Source["path"] = source.NFS.Path
+		desc.Source["readOnly"] = strconv.FormatBool(source.NFS.ReadOnly)
+	case source.CSI != nil:
+		desc.Type = "CSI"
+		desc.Source["driver"] = source.CSI.Driver
+		desc.Source["readOnly"] = strconv.FormatBool(source.CSI.ReadOnly != nil && *source.CSI.ReadOnly)
+	case source.DownwardAPI != nil:
+		desc.Type = "DownwardAPI"
+	case source.Projected != nil:
+		desc.Type = "Projected"
+		desc.Source["sources"] = projectedSources(source.Projected.Sources)
+	default:
+		// 그 외 volume source 는 종류만 표시
+		desc.Type = volumeSourceType(source)
+	}
+
+	if len(desc.Source) == 0 {
+		desc.Source = nil
+	}
+
+	return desc
+}
+
+func projectedSources(sources []v1.VolumeProjection) string {
+	names := make([]string, 0, len(sources))
+	for _, v := range sources {
+		switch {
+		case v.ServiceAccountToken != nil:
+			names = append(names, "ServiceAccountToken")
+		case v.ConfigMap != nil:
+			names = append(names, "ConfigMap("+v.ConfigMap.Name+")")
+		case v.Secret != nil:
+			names = append(names, "Secret("+v.Secret.Name+")")
+		case v.DownwardAPI != nil:
+			names = append(names, "DownwardAPI")
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// VolumeSource 중 설정된 field 이름
+func volumeSourceType(source v1.VolumeSource) string {
+	rv := reflect.ValueOf(source)
+	for i := 0; i < rv.NumField(); i++ {
+		if rv.Field(i).Kind() == reflect.Ptr && !rv.Field(i).IsNil() {
+			return rv.Type().Field(i).Name
+		}
+	}
+	return "Unknown"
+}
+
+// kubectl describe pod 형식의 text
+func (p *PodDescription) Text() string {
+	var buf bytes.Buffer
+	w := &describeWriter{tabwriter.NewWriter(&buf, 0, 8, 2, ' ', 0)}
+
+	w.write(0, "Name:\t%s\n", p.Name)
+	w.write(0, "Namespace:\t%s\n", p.Namespace)
+	if p.Priority != nil {
+		w.write(0, "Priority:\t%d\n", *p.Priority)
+	}
+	if p.PriorityClassName != "" {
+		w.write(0, "Priority Class Name:\t%s\n", p.PriorityClassName)
+	}
+	w.write(0, "Service Account:\t%s\n", orNone(p.ServiceAccount))
+	w.write(0, "Node:\t%s\n", orNone(p.Node))
+	w.write(0, "Start Time:\t%s\n", orNone(p.StartTime))
+	w.writeMap(0, "Labels", p.Labels, "=")
+	w.writeMap(0, "Annotations", p.Annotations, ": ")
+	w.write(0, "Status:\t%s\n", p.Status)
+	if p.Reason != "" {
+		w.write(0, "Reason:\t%s\n", p.Reason)
+	}
+	if p.Message != "" {
+		w.write(0, "Message:\t%s\n", p.Message)
+	}
+	w.write(0, "IP:\t%s\n", p.IP)
+	if len(p.IPs) == 0 {
+		w.write(0, "IPs:\t<none>\n")
+	} else {
+		w.write(0, "IPs:\n")
+		for _, ip := range p.IPs {
+			w.write(1, "IP:\t%s\n", ip)
+		}
+	}
+	for _, v := range p.OwnerReferences {
+		if v.Controller {
+			w.write(0, "Controlled By:\t%s/%s\n", v.Kind, v.Name)
+		}
+	}
+
+	if len(p.InitContainers) > 0 {
+		w.write(0, "Init Containers:\n")
+		w.writeContainers(p.InitContainers)
+	}
+	w.write(0, "Containers:\n")
+	w.writeContainers(p.Containers)
+
+	if len(p.Conditions) > 0 {
+		w.write(0, "Conditions:\n")
+		w.write(1, "Type\tStatus\n")
+		for _, v := range p.Conditions {
+			w.write(1, "%s\t%s\n", v.Type, v.Status)
+		}
+	}
+
+	if len(p.Volumes) == 0 {
+		w.write(0, "Volumes:\t<none>\n")
+	} else {
+		w.write(0, "Volumes:\n")
+		for _, v := range p.Volumes {
+			w.write(1, "%s:\n", v.Name)
+			w.write(2, "Type:\t%s\n", v.Type)
+			for _, key := range sortedKeys(v.Source) {
+				w.write(2, "%s:\t%s\n", key, v.Source[key])
+			}
+		}
+	}
+
+	w.write(0, "QoS Class:\t%s\n", orNone(p.QOSClass))
+	w.writeMap(0, "Node-Selectors", p.NodeSelector, "=")
+
+	if len(p.Tolerations) == 0 {
+		w.write(0, "Tolerations:\t<none>\n")
+	} else {
+		for i, v := range p.Tolerations {
+			label := ""
+			if i == 0 {
+				label = "Tolerations:"
+			}
+			w.write(0, "%s\t%s\n", label, tolerationText(v))
+		}
+	}
+
+	w.w.Flush()
+	return buf.String()
+}
+
+type describeWriter struct {
+	w *tabwriter.Writer
+}
+
+func (d *describeWriter) write(level int, format string, a ...interface{}) {
+	fmt.Fprintf(d.w, strings.Repeat("  ", level)+format, a...)
+}
+
+// map 은 key 순으로 한 줄씩 출력
+func (d *describeWriter) writeMap(level int, title string, m map[string]string, separator string) {
+	if len(m) == 0 {
+		d.write(level, "%s:\t<none>\n", title)
+		return
+	}
+	for i, key := range sortedKeys(m) {
+		label := ""
+		if i == 0 {
+			label = title + ":"
+		}
+		d.write(level, "%s\t%s%s%s\n", label, key, separator, m[key])
+	}
+}
+
+func (d *describeWriter) writeContainers(containers []*ContainerDescription) {
+	for _, v := range containers {
+		d.write(1, "%s:\n", v.Name)
+		d.write(2, "Container ID:\t%s\n", v.ContainerID)
+		d.write(2, "Image:\t%s\n", v.Image)
+		d.write(2, "Image ID:\t%s\n", v.ImageID)
+		if len(v.Ports) == 0 {
+			d.write(2, "Port:\t<none>\n")
+		}
+		for _, port := range v.Ports {
+			d.write(2, "Port:\t%d/%s\n", port.ContainerPort, port.Protocol)
+			d.write(2, "Host Port:\t%d/%s\n", port.HostPort, port.Protocol)
+		}
+		if len(v.Command) > 0 {
+			d.write(2, "Command:\t%s\n", strings.Join(v.Command, " "))
+		}
+		if len(v.Args) > 0 {
+			d.write(2, "Args:\t%s\n", strings.Join(v.Args, " "))
+		}
+		d.writeContainerState(2, "State", v.State)
+		if v.LastState != nil {
+			d.writeContainerState(2, "Last State", v.LastState)
+		}
+		d.write(2, "Ready:\t%s\n", boolText(v.Ready))
+		d.write(2, "Restart Count:\t%d\n", v.RestartCount)
+		d.writeResources("Limits", v.Limits)
+		d.writeResources("Requests", v.Requests)
+		d.writeProbe("Liveness", v.LivenessProbe)
+		d.writeProbe("Readiness", v.ReadinessProbe)
+		d.writeProbe("Startup", v.StartupProbe)
+
+		if len(v.Env) == 0 {
+			d.write(2, "Environment:\t<none>\n")
+		} else {
+			d.write(2, "Environment:\n")
+			for _, env := range v.Env {
+				if env.From != "" {
+					d.write(3, "%s:\t<set to %s>\n", env.Name, env.From)
+				} else {
+					d.write(3, "%s:\t%s\n", env.Name, env.Value)
+				}
+			}
+		}
+
+		if len(v.VolumeMounts) == 0 {
+			d.write(2, "Mounts:\t<none>\n")
+		} else {
+			d.write(2, "Mounts:\n")
+			for _, mount := range v.VolumeMounts {
+				mode := "rw"
+				if mount.ReadOnly {
+					mode = "ro"
+				}
+				subPath := ""
+				if mount.SubPath != "" {
+					subPath = ", path = \"" + mount.SubPath + "\""
+				}
+				d.write(3, "%s from %s (%s%s)\n", mount.MountPath, mount.Name, mode, subPath)
+			}
+		}
+	}
+}
+
+func (d *describeWriter) writeContainerState(level int, title string, state *ContainerState) {
+	if state == nil {
+		d.write(level, "%s:\tWaiting\n", title)
+		return
+	}
+	d.write(level, "%s:\t%s\n", title, state.State)
+	if state.Reason != "" {
+		d.write(level+1, "Reason:\t%s\n", state.Reason)
+	}
+	if state.Message != "" {
+		d.write(level+1, "Message:\t%s\n", state.Message)
+	}
+	if state.ExitCode != nil {
+		d.write(level+1, "Exit Code:\t%d\n", *state.ExitCode)
+	}
+	if state.StartedAt != "" {
+		d.write(level+1, "Started:\t%s\n", state.StartedAt)
+	}
+	if state.FinishedAt != "" {
+		d.write(level+1, "Finished:\t%s\n", state.FinishedAt)
+	}
+}
+
+func (d *describeWriter) writeResources(title string, resources map[string]string) {
+	if len(resources) == 0 {
+		return
+	}
+	d.write(2, "%s:\n", title)
+	for _, key := range sortedKeys(resources) {
+		d.write(3, "%s:\t%s\n", key, resources[key])
+	}
+}
+
+func (d *describeWriter) writeProbe(title string, probe *Probe) {
+	if probe == nil {
+		return
+	}
+	d.write(2, "%s:\t%s %s delay=%ds timeout=%ds period=%ds #success=%d #failure=%d\n",
+		title, probe.Type, probe.Target, probe.InitialDelaySeconds, probe.TimeoutSeconds,
+		probe.PeriodSeconds, probe.SuccessThreshold, probe.FailureThreshold)
+}
+
+func tolerationText(t *Toleration) string {
+	text := t.Key
+	if t.Value != "" {
+		text += "=" + t.Value
+	}
+	if t.Effect != "" {
+		text += ":" + t.Effect
+	}
+	if t.Operator == string(v1.TolerationOpExists) && t.Value == "" {
+		if t.Key != "" {
+			text += " "
+		}
+		text += "op=Exists"
+	}
+	if t.TolerationSeconds != nil {
+		text += fmt.Sprintf(" for %ds", *t.TolerationSeconds)
+	}
+	return text
+}
+
+func boolText(b bool) string {
+	if b {
+		return "True"
+	}
+	return "False"
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "<none>"
+	}
+	return s
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
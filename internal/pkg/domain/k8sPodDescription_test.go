@@ -0,0 +1,148 @@
+package domain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newDescribeTestPod() *v1.Pod {
+	controller := true
+	priority := int32(0)
+	tolerationSeconds := int64(300)
+	startTime := metav1.NewTime(time.Date(2023, 3, 1, 9, 0, 0, 0, time.UTC))
+
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "web-7d4b9c-abcde",
+			Namespace:   "default",
+			Labels:      map[string]string{"app": "web", "pod-template-hash": "7d4b9c"},
+			Annotations: map[string]string{"team": "platform"},
+			OwnerReferences: []metav1.OwnerReference{
+				{Kind: "ReplicaSet", Name: "web-7d4b9c", Controller: &controller},
+			},
+		},
+		Spec: v1.PodSpec{
+			Priority:           &priority,
+			ServiceAccountName: "default",
+			NodeName:           "node-1",
+			Containers: []v1.Container{{
+				Name:  "web",
+				Image: "nginx:1.23",
+				Ports: []v1.ContainerPort{{ContainerPort: 80, Protocol: v1.ProtocolTCP}},
+				Env: []v1.EnvVar{
+					{Name: "MODE", Value: "prod"},
+					{Name: "PASSWORD", ValueFrom: &v1.EnvVarSource{SecretKeyRef: &v1.SecretKeySelector{
+						LocalObjectReference: v1.LocalObjectReference{Name: "db"}, Key: "password"}}},
+				},
+				Resources: v1.ResourceRequirements{
+					Limits:   v1.ResourceList{v1.ResourceCPU: resource.MustParse("500m"), v1.ResourceMemory: resource.MustParse("128Mi")},
+					Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("250m")},
+				},
+				LivenessProbe: &v1.Probe{
+					ProbeHandler:     v1.ProbeHandler{HTTPGet: &v1.HTTPGetAction{Path: "/healthz", Port: intstr.FromInt(80)}},
+					TimeoutSeconds:   1,
+					PeriodSeconds:    10,
+					SuccessThreshold: 1,
+					FailureThreshold: 3,
+				},
+				ReadinessProbe: &v1.Probe{
+					ProbeHandler: v1.ProbeHandler{TCPSocket: &v1.TCPSocketAction{Port: intstr.FromInt(80)}},
+				},
+				VolumeMounts: []v1.VolumeMount{{Name: "config", MountPath: "/etc/nginx", ReadOnly: true}},
+			}},
+			Volumes: []v1.Volume{
+				{Name: "config", VolumeSource: v1.VolumeSource{ConfigMap: &v1.ConfigMapVolumeSource{
+					LocalObjectReference: v1.LocalObjectReference{Name: "nginx-config"}}}},
+				{Name: "cache", VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}},
+				{Name: "git", VolumeSource: v1.VolumeSource{GitRepo: &v1.GitRepoVolumeSource{Repository: "repo"}}},
+			},
+			Tolerations: []v1.Toleration{
+				{Key: "node.kubernetes.io/not-ready", Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoExecute, TolerationSeconds: &tolerationSeconds},
+			},
+		},
+		Status: v1.PodStatus{
+			Phase:     v1.PodRunning,
+			PodIP:     "10.0.0.5",
+			PodIPs:    []v1.PodIP{{IP: "10.0.0.5"}},
+			QOSClass:  v1.PodQOSBurstable,
+			StartTime: &startTime,
+			Conditions: []v1.PodCondition{
+				{Type: v1.PodReady, Status: v1.ConditionTrue},
+				{Type: v1.PodScheduled, Status: v1.ConditionTrue},
+			},
+			ContainerStatuses: []v1.ContainerStatus{{
+				Name:         "web",
+				ContainerID:  "containerd://abc",
+				Image:        "nginx:1.23",
+				Ready:        true,
+				RestartCount: 2,
+				State:        v1.ContainerState{Running: &v1.ContainerStateRunning{StartedAt: startTime}},
+				LastTerminationState: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{
+					Reason: "OOMKilled", ExitCode: 137}},
+			}},
+		},
+	}
+}
+
+func TestGetPodDesc(t *testing.T) {
+	assert := assert.New(t)
+
+	k := NewK8sClientSetHandlerWithClientSet(fake.NewSimpleClientset(newDescribeTestPod()))
+
+	desc, err := k.GetPodDesc(context.Background(), "default", "web-7d4b9c-abcde")
+	assert.NoError(err)
+
+	assert.Equal("node-1", desc.Node)
+	assert.Equal("2023-03-01T09:00:00Z", desc.StartTime)
+	assert.Equal("Burstable", desc.QOSClass)
+	assert.Equal([]string{"10.0.0.5"}, desc.IPs)
+	assert.Equal(&OwnerReference{Kind: "ReplicaSet", Name: "web-7d4b9c", Controller: true}, desc.OwnerReferences[0])
+
+	container := desc.Containers[0]
+	assert.Equal("containerd://abc", container.ContainerID)
+	assert.Equal("Running", container.State.State)
+	assert.Equal("OOMKilled", container.LastState.Reason)
+	assert.Equal(int32(137), *container.LastState.ExitCode)
+	assert.Equal(map[string]string{"cpu": "500m", "memory": "128Mi"}, container.Limits)
+	assert.Equal(&Probe{Type: "http-get", Target: "http://:80/healthz", TimeoutSeconds: 1, PeriodSeconds: 10, SuccessThreshold: 1, FailureThreshold: 3}, container.LivenessProbe)
+	assert.Equal("tcp-socket", container.ReadinessProbe.Type)
+	assert.Nil(container.StartupProbe)
+	assert.Equal(&EnvVar{Name: "PASSWORD", From: "secret db/password"}, container.Env[1])
+
+	assert.Equal(&VolumeDescription{Name: "config", Type: "ConfigMap", Source: map[string]string{"name": "nginx-config", "optional": "false"}}, desc.Volumes[0])
+	assert.Equal(&VolumeDescription{Name: "cache", Type: "EmptyDir", Source: map[string]string{"medium": ""}}, desc.Volumes[1])
+	assert.Equal(&VolumeDescription{Name: "git", Type: "GitRepo"}, desc.Volumes[2])
+
+	assert.Equal(2, len(desc.Conditions))
+	assert.Equal("Ready", desc.Conditions[0].Type)
+	assert.Equal(int64(300), *desc.Tolerations[0].TolerationSeconds)
+
+	_, err = k.GetPodDesc(context.Background(), "default", "unknown")
+	assert.Error(err)
+}
+
+func TestPodDescriptionText(t *testing.T) {
+	assert := assert.New(t)
+
+	text := createPodDescription(newDescribeTestPod()).Text()
+	t.Log("\n" + text)
+
+	assert.Contains(text, "Name:             web-7d4b9c-abcde\n")
+	assert.Contains(text, "Labels:           app=web\n                  pod-template-hash=7d4b9c\n")
+	assert.Contains(text, "IPs:\n  IP:           10.0.0.5\nControlled By:  ReplicaSet/web-7d4b9c\n")
+	assert.Contains(text, "    Last State:     Terminated\n      Reason:       OOMKilled\n      Exit Code:    137\n    Ready:          True\n")
+	assert.Contains(text, "    Liveness:   http-get http://:80/healthz delay=0s timeout=1s period=10s #success=1 #failure=3\n")
+	assert.Contains(text, "      PASSWORD:  <set to secret db/password>\n")
+	assert.Contains(text, "      /etc/nginx from config (ro)\n")
+	assert.Contains(text, "  Type          Status\n  Ready         True\n  PodScheduled  True\n")
+	assert.Contains(text, "Tolerations:     node.kubernetes.io/not-ready:NoExecute op=Exists for 300s\n")
+	assert.NotContains(text, "Messae")
+}
@@ -0,0 +1,363 @@
+package domain
+
+import (
+	"backend/internal/pkg/ctxutil"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var (
+	ErrRevisionNotFound = errors.New("deployment revision not found")
+	ErrDeploymentPaused = errors.New("deployment is paused")
+)
+
+const (
+	restartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+	revisionAnnotation    = "deployment.kubernetes.io/revision"
+)
+
+// rollout 상태 확인 주기
+var rolloutPollInterval = time.Second
+
+func (k *K8sClientSetHandler) ScaleWorkload(ctx context.Context, kind WorkloadKind, namespace, name string, replicas int32) error {
+	if kind != Deployments && kind != StatefulSets {
+		return fmt.Errorf("%w: scale is not supported for %s", ErrUnsupportedWorkloadKind, kind)
+	}
+
+	patch := map[string]interface{}{
+		"spec": map[string]interface{}{"replicas": replicas},
+	}
+
+	ctx, cancel := ctxutil.WithTimeout(ctx, k.timeout)
+	defer cancel()
+
+	if err := k.patchWorkload(ctx, kind, namespace, name, patch); err != nil {
+		log.Printf("ScaleWorkload returned error: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// kubectl rollout restart 와 동일하게 pod template annotation 을 변경
+func (k *K8sClientSetHandler) RestartWorkload(ctx context.Context, kind WorkloadKind, namespace, name string) error {
+	ctx, cancel := ctxutil.WithTimeout(ctx, k.timeout)
+	defer cancel()
+
+	switch kind {
+	case Deployments:
+		deployment, err := k.clientSet.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			log.Printf("RestartWorkload returned error: %v", err)
+			return err
+		}
+		if deployment.Spec.Paused {
+			return fmt.Errorf("%w: resume the rollout before restart", ErrDeploymentPaused)
+		}
+	case StatefulSets, DaemonSets:
+	default:
+		return fmt.Errorf("%w: restart is not supported for %s", ErrUnsupportedWorkloadKind, kind)
+	}
+
+	patch := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"annotations": map[string]string{
+						restartedAtAnnotation: time.Now().Format(time.RFC3339),
+					},
+				},
+			},
+		},
+	}
+
+	if err := k.patchWorkload(ctx, kind, namespace, name, patch); err != nil {
+		log.Printf("RestartWorkload returned error: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func (k *K8sClientSetHandler) SetDeploymentPaused(ctx context.Context, namespace, name string, paused bool) error {
+	patch := map[string]interface{}{
+		"spec": map[string]interface{}{"paused": paused},
+	}
+
+	ctx, cancel := ctxutil.WithTimeout(ctx, k.timeout)
+	defer cancel()
+
+	if err := k.patchWorkload(ctx, Deployments, namespace, name, patch); err != nil {
+		log.Printf("SetDeploymentPaused returned error: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func (k *K8sClientSetHandler) patchWorkload(ctx context.Context, kind WorkloadKind, namespace, name string, patch map[string]interface{}) error {
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return err
+	}
+
+	opts := metav1.PatchOptions{}
+
+	switch kind {
+	case Deployments:
+		_, err = k.clientSet.AppsV1().Deployments(namespace).Patch(ctx, name, types.MergePatchType, data, opts)
+	case StatefulSets:
+		_, err = k.clientSet.AppsV1().StatefulSets(namespace).Patch(ctx, name, types.MergePatchType, data, opts)
+	case DaemonSets:
+		_, err = k.clientSet.AppsV1().DaemonSets(namespace).Patch(ctx, name, types.MergePatchType, data, opts)
+	default:
+		err = fmt.Errorf("%w: %s", ErrUnsupportedWorkloadKind, kind)
+	}
+
+	return err
+}
+
+// deployment 가 소유한 replicaset 중 revision 이 일치하는 template 으로 되돌림
+//
+// revision 이 0 이면 현재 바로 이전 revision 사용
+func (k *K8sClientSetHandler) RollbackDeployment(ctx context.Context, namespace, name string, revision int64) error {
+	ctx, cancel := ctxutil.WithTimeout(ctx, k.timeout)
+	defer cancel()
+
+	deployment, err := k.clientSet.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		log.Printf("RollbackDeployment returned error: %v", err)
+		return err
+	}
+
+	if deployment.Spec.Paused {
+		return fmt.Errorf("%w: resume the rollout before rollback", ErrDeploymentPaused)
+	}
+
+	selector := formatSelector(deployment.Spec.Selector)
+	if selector == "" {
+		return fmt.Errorf("%w: deployment %q has no selector", ErrRevisionNotFound, name)
+	}
+
+	replicaSets, err := k.clientSet.AppsV1().ReplicaSets(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		log.Printf("RollbackDeployment returned error: %v", err)
+		return err
+	}
+
+	target := findRollbackReplicaSet(deployment, replicaSets.Items, revision)
+	if target == nil {
+		return fmt.Errorf("%w: %d", ErrRevisionNotFound, revision)
+	}
+
+	template := target.Spec.Template.DeepCopy()
+	delete(template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+	deployment.Spec.Template = *template
+
+	if _, err := k.clientSet.AppsV1().Deployments(namespace).Update(ctx, deployment, metav1.UpdateOptions{}); err != nil {
+		log.Printf("RollbackDeployment returned error: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func findRollbackReplicaSet(deployment *appsv1.Deployment, replicaSets []appsv1.ReplicaSet, revision int64) *appsv1.ReplicaSet {
+	var current, previous *appsv1.ReplicaSet
+	var currentRevision, previousRevision int64
+
+	for i := range replicaSets {
+		rs := &replicaSets[i]
+		if !metav1.IsControlledBy(rs, deployment) {
+			continue
+		}
+
+		v, err := strconv.ParseInt(rs.Annotations[revisionAnnotation], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		if revision > 0 && v == revision {
+			return rs
+		}
+
+		if v > currentRevision {
+			previous, previousRevision = current, currentRevision
+			current, currentRevision = rs, v
+		} else if v > previousRevision {
+			previous, previousRevision = rs, v
+		}
+	}
+
+	if revision > 0 {
+		return nil
+	}
+
+	return previous
+}
+
+// 완료, 실패 또는 timeout 까지 대기
+//
+// timeout 은 error 가 아니며 Complete 가 false 인 상태를 반환
+func (k *K8sClientSetHandler) GetRolloutStatus(ctx context.Context, kind WorkloadKind, namespace, name string, timeout time.Duration) (*RolloutStatus, error) {
+	deadline := time.Now().Add(timeout)
+
+	ticker := time.NewTicker(rolloutPollInterval)
+	defer ticker.Stop()
+
+	for {
+		status, err := k.getRolloutStatus(ctx, kind, namespace, name)
+		if err != nil {
+			log.Printf("GetRolloutStatus returned error: %v", err)
+			return nil, err
+		}
+
+		if status.Complete || status.Failed {
+			return status, nil
+		}
+
+		if !time.Now().Before(deadline) {
+			status.Message = "timed out: " + status.Message
+			return status, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (k *K8sClientSetHandler) getRolloutStatus(ctx context.Context, kind WorkloadKind, namespace, name string) (*RolloutStatus, error) {
+	ctx, cancel := ctxutil.WithTimeout(ctx, k.timeout)
+	defer cancel()
+
+	opts := metav1.GetOptions{}
+
+	switch kind {
+	case Deployments:
+		deployment, err := k.clientSet.AppsV1().Deployments(namespace).Get(ctx, name, opts)
+		if err != nil {
+			return nil, err
+		}
+		return deploymentRolloutStatus(deployment), nil
+	case StatefulSets:
+		statefulSet, err := k.clientSet.AppsV1().StatefulSets(namespace).Get(ctx, name, opts)
+		if err != nil {
+			return nil, err
+		}
+		return statefulSetRolloutStatus(statefulSet), nil
+	case DaemonSets:
+		daemonSet, err := k.clientSet.AppsV1().DaemonSets(namespace).Get(ctx, name, opts)
+		if err != nil {
+			return nil, err
+		}
+		return daemonSetRolloutStatus(daemonSet), nil
+	}
+
+	return nil, fmt.Errorf("%w: rollout status is not supported for %s", ErrUnsupportedWorkloadKind, kind)
+}
+
+// kubectl rollout status 와 같은 기준으로 판단
+func deploymentRolloutStatus(d *appsv1.Deployment) *RolloutStatus {
+	status := &RolloutStatus{
+		Kind:      "Deployment",
+		Name:      d.Name,
+		Desired:   replicasOrDefault(d.Spec.Replicas),
+		Updated:   d.Status.UpdatedReplicas,
+		Ready:     d.Status.ReadyReplicas,
+		Available: d.Status.AvailableReplicas,
+	}
+
+	if d.Generation > d.Status.ObservedGeneration {
+		status.Message = "waiting for deployment spec update to be observed"
+		return status
+	}
+
+	for _, v := range d.Status.Conditions {
+		if v.Type == appsv1.DeploymentProgressing && v.Reason == "ProgressDeadlineExceeded" {
+			status.Failed = true
+			status.Message = fmt.Sprintf("deployment %q exceeded its progress deadline", d.Name)
+			return status
+		}
+	}
+
+	if d.Spec.Paused {
+		status.Message = fmt.Sprintf("deployment %q is paused", d.Name)
+		return status
+	}
+
+	switch {
+	case status.Updated < status.Desired:
+		status.Message = fmt.Sprintf("waiting for deployment %q rollout to finish: %d out of %d new replicas have been updated", d.Name, status.Updated, status.Desired)
+	case d.Status.Replicas > status.Updated:
+		status.Message = fmt.Sprintf("waiting for deployment %q rollout to finish: %d old replicas are pending termination", d.Name, d.Status.Replicas-status.Updated)
+	case status.Available < status.Updated:
+		status.Message = fmt.Sprintf("waiting for deployment %q rollout to finish: %d of %d updated replicas are available", d.Name, status.Available, status.Updated)
+	default:
+		status.Complete = true
+		status.Message = fmt.Sprintf("deployment %q successfully rolled out", d.Name)
+	}
+
+	return status
+}
+
+func statefulSetRolloutStatus(s *appsv1.StatefulSet) *RolloutStatus {
+	status := &RolloutStatus{
+		Kind:      "StatefulSet",
+		Name:      s.Name,
+		Desired:   replicasOrDefault(s.Spec.Replicas),
+		Updated:   s.Status.UpdatedReplicas,
+		Ready:     s.Status.ReadyReplicas,
+		Available: s.Status.AvailableReplicas,
+	}
+
+	switch {
+	case s.Status.ObservedGeneration == 0 || s.Generation > s.Status.ObservedGeneration:
+		status.Message = "waiting for statefulset spec update to be observed"
+	case status.Ready < status.Desired:
+		status.Message = fmt.Sprintf("waiting for %d pods to be ready", status.Desired-status.Ready)
+	case s.Spec.UpdateStrategy.Type != appsv1.OnDeleteStatefulSetStrategyType && s.Status.UpdateRevision != s.Status.CurrentRevision:
+		status.Message = fmt.Sprintf("waiting for statefulset rolling update to complete %d pods at revision %s", status.Updated, s.Status.UpdateRevision)
+	default:
+		status.Complete = true
+		status.Message = fmt.Sprintf("statefulset rolling update complete %d pods at revision %s", status.Ready, s.Status.CurrentRevision)
+	}
+
+	return status
+}
+
+func daemonSetRolloutStatus(d *appsv1.DaemonSet) *RolloutStatus {
+	status := &RolloutStatus{
+		Kind:      "DaemonSet",
+		Name:      d.Name,
+		Desired:   d.Status.DesiredNumberScheduled,
+		Updated:   d.Status.UpdatedNumberScheduled,
+		Ready:     d.Status.NumberReady,
+		Available: d.Status.NumberAvailable,
+	}
+
+	switch {
+	case d.Generation > d.Status.ObservedGeneration:
+		status.Message = "waiting for daemon set spec update to be observed"
+	case status.Updated < status.Desired:
+		status.Message = fmt.Sprintf("waiting for daemon set %q rollout to finish: %d out of %d new pods have been updated", d.Name, status.Updated, status.Desired)
+	case status.Available < status.Desired:
+		status.Message = fmt.Sprintf("waiting for daemon set %q rollout to finish: %d of %d updated pods are available", d.Name, status.Available, status.Desired)
+	default:
+		status.Complete = true
+		status.Message = fmt.Sprintf("daemon set %q successfully rolled out", d.Name)
+	}
+
+	return status
+}
@@ -0,0 +1,225 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newRolloutTestDeployment() *appsv1.Deployment {
+	replicas := int32(2)
+	labels := map[string]string{"app": "web"}
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", UID: types.UID("web-uid"), Generation: 1},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: newWorkloadTestTemplate(labels, "nginx:1.24"),
+		},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: 1,
+			Replicas:           2,
+			UpdatedReplicas:    2,
+			ReadyReplicas:      2,
+			AvailableReplicas:  2,
+		},
+	}
+}
+
+func newRolloutTestReplicaSet(d *appsv1.Deployment, revision, image string) *appsv1.ReplicaSet {
+	labels := map[string]string{"app": "web", appsv1.DefaultDeploymentUniqueLabelKey: "hash-" + revision}
+
+	return &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "web-" + revision,
+			Namespace:       "default",
+			Labels:          labels,
+			Annotations:     map[string]string{revisionAnnotation: revision},
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(d, appsv1.SchemeGroupVersion.WithKind("Deployment"))},
+		},
+		Spec: appsv1.ReplicaSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: newWorkloadTestTemplate(labels, image),
+		},
+	}
+}
+
+func TestScaleAndRestartWorkload(t *testing.T) {
+	assert := assert.New(t)
+
+	deployment := newRolloutTestDeployment()
+	clientSet := fake.NewSimpleClientset(deployment)
+	handler := NewK8sClientSetHandlerWithClientSet(clientSet)
+	ctx := context.Background()
+
+	assert.NoError(handler.ScaleWorkload(context.Background(), Deployments, "default", "web", 5))
+	d, _ := clientSet.AppsV1().Deployments("default").Get(ctx, "web", metav1.GetOptions{})
+	assert.Equal(int32(5), *d.Spec.Replicas)
+
+	err := handler.ScaleWorkload(context.Background(), DaemonSets, "default", "web", 1)
+	assert.True(errors.Is(err, ErrUnsupportedWorkloadKind))
+
+	assert.NoError(handler.RestartWorkload(context.Background(), Deployments, "default", "web"))
+	d, _ = clientSet.AppsV1().Deployments("default").Get(ctx, "web", metav1.GetOptions{})
+	_, err = time.Parse(time.RFC3339, d.Spec.Template.Annotations[restartedAtAnnotation])
+	assert.NoError(err)
+
+	// paused 상태에서는 restart 불가
+	assert.NoError(handler.SetDeploymentPaused(context.Background(), "default", "web", true))
+	d, _ = clientSet.AppsV1().Deployments("default").Get(ctx, "web", metav1.GetOptions{})
+	assert.True(d.Spec.Paused)
+
+	err = handler.RestartWorkload(context.Background(), Deployments, "default", "web")
+	assert.True(errors.Is(err, ErrDeploymentPaused))
+
+	assert.NoError(handler.SetDeploymentPaused(context.Background(), "default", "web", false))
+	d, _ = clientSet.AppsV1().Deployments("default").Get(ctx, "web", metav1.GetOptions{})
+	assert.False(d.Spec.Paused)
+
+	err = handler.RestartWorkload(context.Background(), Jobs, "default", "web")
+	assert.True(errors.Is(err, ErrUnsupportedWorkloadKind))
+}
+
+func TestRollbackDeployment(t *testing.T) {
+	assert := assert.New(t)
+
+	deployment := newRolloutTestDeployment()
+	deployment.Spec.Template = newWorkloadTestTemplate(map[string]string{"app": "web"}, "nginx:1.24")
+
+	// 다른 deployment 소유 replicaset 은 무시
+	other := newRolloutTestReplicaSet(deployment, "9", "other:1")
+	other.OwnerReferences[0].UID = types.UID("other-uid")
+
+	clientSet := fake.NewSimpleClientset(
+		deployment,
+		newRolloutTestReplicaSet(deployment, "1", "nginx:1.22"),
+		newRolloutTestReplicaSet(deployment, "2", "nginx:1.23"),
+		newRolloutTestReplicaSet(deployment, "3", "nginx:1.24"),
+		other,
+	)
+	handler := NewK8sClientSetHandlerWithClientSet(clientSet)
+	ctx := context.Background()
+
+	// 0 이면 직전 revision
+	assert.NoError(handler.RollbackDeployment(context.Background(), "default", "web", 0))
+	d, _ := clientSet.AppsV1().Deployments("default").Get(ctx, "web", metav1.GetOptions{})
+	assert.Equal("nginx:1.23", d.Spec.Template.Spec.Containers[0].Image)
+	assert.NotContains(d.Spec.Template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+
+	assert.NoError(handler.RollbackDeployment(context.Background(), "default", "web", 1))
+	d, _ = clientSet.AppsV1().Deployments("default").Get(ctx, "web", metav1.GetOptions{})
+	assert.Equal("nginx:1.22", d.Spec.Template.Spec.Containers[0].Image)
+
+	err := handler.RollbackDeployment(context.Background(), "default", "web", 9)
+	assert.True(errors.Is(err, ErrRevisionNotFound))
+
+	assert.NoError(handler.SetDeploymentPaused(context.Background(), "default", "web", true))
+	err = handler.RollbackDeployment(context.Background(), "default", "web", 0)
+	assert.True(errors.Is(err, ErrDeploymentPaused))
+}
+
+func TestGetRolloutStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	defaultInterval := rolloutPollInterval
+	rolloutPollInterval = 10 * time.Millisecond
+	defer func() { rolloutPollInterval = defaultInterval }()
+
+	deployment := newRolloutTestDeployment()
+	deployment.Status.UpdatedReplicas = 1
+	deployment.Status.AvailableReplicas = 1
+
+	clientSet := fake.NewSimpleClientset(deployment)
+	handler := NewK8sClientSetHandlerWithClientSet(clientSet)
+	ctx := context.Background()
+
+	// timeout 시 미완료 상태 반환
+	status, err := handler.GetRolloutStatus(ctx, Deployments, "default", "web", 30*time.Millisecond)
+	assert.NoError(err)
+	assert.False(status.Complete)
+	assert.False(status.Failed)
+	assert.Contains(status.Message, "timed out")
+	assert.Contains(status.Message, "1 out of 2 new replicas have been updated")
+
+	// 대기 중 rollout 완료
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		d := newRolloutTestDeployment()
+		clientSet.AppsV1().Deployments("default").UpdateStatus(ctx, d, metav1.UpdateOptions{})
+	}()
+
+	status, err = handler.GetRolloutStatus(ctx, Deployments, "default", "web", 5*time.Second)
+	assert.NoError(err)
+	assert.True(status.Complete)
+	assert.Equal(`deployment "web" successfully rolled out`, status.Message)
+
+	// progress deadline 초과 시 즉시 실패
+	d := newRolloutTestDeployment()
+	d.Status.UpdatedReplicas = 1
+	d.Status.Conditions = []appsv1.DeploymentCondition{
+		{Type: appsv1.DeploymentProgressing, Status: v1.ConditionFalse, Reason: "ProgressDeadlineExceeded"},
+	}
+	clientSet.AppsV1().Deployments("default").UpdateStatus(ctx, d, metav1.UpdateOptions{})
+
+	status, err = handler.GetRolloutStatus(ctx, Deployments, "default", "web", 5*time.Second)
+	assert.NoError(err)
+	assert.True(status.Failed)
+
+	_, err = handler.GetRolloutStatus(ctx, Jobs, "default", "web", time.Second)
+	assert.True(errors.Is(err, ErrUnsupportedWorkloadKind))
+
+	_, err = handler.GetRolloutStatus(ctx, Deployments, "default", "unknown", time.Second)
+	assert.Error(err)
+}
+
+func TestStatefulSetAndDaemonSetRolloutStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	replicas := int32(3)
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Generation: 2},
+		Spec:       appsv1.StatefulSetSpec{Replicas: &replicas},
+		Status: appsv1.StatefulSetStatus{
+			ObservedGeneration: 2,
+			ReadyReplicas:      3,
+			UpdatedReplicas:    1,
+			CurrentRevision:    "db-1",
+			UpdateRevision:     "db-2",
+		},
+	}
+
+	status := statefulSetRolloutStatus(statefulSet)
+	assert.False(status.Complete)
+	assert.Contains(status.Message, "waiting for statefulset rolling update")
+
+	statefulSet.Status.CurrentRevision = "db-2"
+	status = statefulSetRolloutStatus(statefulSet)
+	assert.True(status.Complete)
+
+	daemonSet := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "agent", Generation: 1},
+		Status: appsv1.DaemonSetStatus{
+			ObservedGeneration:     1,
+			DesiredNumberScheduled: 3,
+			UpdatedNumberScheduled: 3,
+			NumberAvailable:        2,
+		},
+	}
+
+	status = daemonSetRolloutStatus(daemonSet)
+	assert.False(status.Complete)
+	assert.Contains(status.Message, "2 of 3 updated pods are available")
+
+	daemonSet.Status.NumberAvailable = 3
+	status = daemonSetRolloutStatus(daemonSet)
+	assert.True(status.Complete)
+}
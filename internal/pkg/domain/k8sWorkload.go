@@ -0,0 +1,321 @@
+package domain
+
+import (
+	"backend/internal/pkg/ctxutil"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+var ErrUnsupportedWorkloadKind = errors.New("unsupported workload kind")
+
+var WorkloadKinds = []WorkloadKind{Deployments, ReplicaSets, StatefulSets, DaemonSets, Jobs, CronJobs}
+
+func (k *K8sClientSetHandler) GetWorkloadList(ctx context.Context, kind WorkloadKind, namespace string) ([]*WorkloadInfo, error) {
+	ctx, cancel := ctxutil.WithTimeout(ctx, k.timeout)
+	defer cancel()
+
+	opts := metav1.ListOptions{}
+
+	var workloads []*WorkloadInfo
+	var err error
+
+	switch kind {
+	case Deployments:
+		var list *appsv1.DeploymentList
+		if list, err = k.clientSet.AppsV1().Deployments(namespace).List(ctx, opts); err == nil {
+			for i := range list.Items {
+				workloads = append(workloads, createDeploymentInfo(&list.Items[i]))
+			}
+		}
+	case ReplicaSets:
+		var list *appsv1.ReplicaSetList
+		if list, err = k.clientSet.AppsV1().ReplicaSets(namespace).List(ctx, opts); err == nil {
+			for i := range list.Items {
+				workloads = append(workloads, createReplicaSetInfo(&list.Items[i]))
+			}
+		}
+	case StatefulSets:
+		var list *appsv1.StatefulSetList
+		if list, err = k.clientSet.AppsV1().StatefulSets(namespace).List(ctx, opts); err == nil {
+			for i := range list.Items {
+				workloads = append(workloads, createStatefulSetInfo(&list.Items[i]))
+			}
+		}
+	case DaemonSets:
+		var list *appsv1.DaemonSetList
+		if list, err = k.clientSet.AppsV1().DaemonSets(namespace).List(ctx, opts); err == nil {
+			for i := range list.Items {
+				workloads = append(workloads, createDaemonSetInfo(&list.Items[i]))
+			}
+		}
+	case Jobs:
+		var list *batchv1.JobList
+		if list, err = k.clientSet.BatchV1().Jobs(namespace).List(ctx, opts); err == nil {
+			for i := range list.Items {
+				workloads = append(workloads, createJobInfo(&list.Items[i]))
+			}
+		}
+	case CronJobs:
+		var list *batchv1.CronJobList
+		if list, err = k.clientSet.BatchV1().CronJobs(namespace).List(ctx, opts); err == nil {
+			for i := range list.Items {
+				workloads = append(workloads, createCronJobInfo(&list.Items[i]))
+			}
+		}
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedWorkloadKind, kind)
+	}
+
+	if err != nil {
+		log.Printf("GetWorkloadList(%s) returned error: %v", kind, err)
+		return nil, err
+	}
+
+	if workloads == nil {
+		workloads = []*WorkloadInfo{}
+	}
+
+	return workloads, nil
+}
+
+// workload 가 관리하는 pod 는 label selector 로 조회
+func (k *K8sClientSetHandler) GetWorkload(ctx context.Context, kind WorkloadKind, namespace, name string) (*WorkloadDetail, error) {
+	ctx, cancel := ctxutil.WithTimeout(ctx, k.timeout)
+	defer cancel()
+
+	opts := metav1.GetOptions{}
+
+	var info *WorkloadInfo
+	var meta metav1.ObjectMeta
+	var err error
+
+	switch kind {
+	case Deployments:
+		var obj *appsv1.Deployment
+		if obj, err = k.clientSet.AppsV1().Deployments(namespace).Get(ctx, name, opts); err == nil {
+			info, meta = createDeploymentInfo(obj), obj.ObjectMeta
+		}
+	case ReplicaSets:
+		var obj *appsv1.ReplicaSet
+		if obj, err = k.clientSet.AppsV1().ReplicaSets(namespace).Get(ctx, name, opts); err == nil {
+			info, meta = createReplicaSetInfo(obj), obj.ObjectMeta
+		}
+	case StatefulSets:
+		var obj *appsv1.StatefulSet
+		if obj, err = k.clientSet.AppsV1().StatefulSets(namespace).Get(ctx, name, opts); err == nil {
+			info, meta = createStatefulSetInfo(obj), obj.ObjectMeta
+		}
+	case DaemonSets:
+		var obj *appsv1.DaemonSet
+		if obj, err = k.clientSet.AppsV1().DaemonSets(namespace).Get(ctx, name, opts); err == nil {
+			info, meta = createDaemonSetInfo(obj), obj.ObjectMeta
+		}
+	case Jobs:
+		var obj *batchv1.Job
+		if obj, err = k.clientSet.BatchV1().Jobs(namespace).Get(ctx, name, opts); err == nil {
+			info, meta = createJobInfo(obj), obj.ObjectMeta
+		}
+	case CronJobs:
+		var obj *batchv1.CronJob
+		if obj, err = k.clientSet.BatchV1().CronJobs(namespace).Get(ctx, name, opts); err == nil {
+			info, meta = createCronJobInfo(obj), obj.ObjectMeta
+			// cronjob 은 selector 가 없으므로 소유한 job 이름으로 selector 생성
+			info.Selector, err = k.getCronJobSelector(ctx, obj)
+		}
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedWorkloadKind, kind)
+	}
+
+	if err != nil {
+		log.Printf("GetWorkload(%s) returned error: %v", kind, err)
+		return nil, err
+	}
+
+	pods, err := k.getPodListBySelector(ctx, namespace, info.Selector)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WorkloadDetail{
+		WorkloadInfo: *info,
+		Labels:       meta.Labels,
+		Annotations:  meta.Annotations,
+		Pods:         pods,
+	}, nil
+}
+
+// selector 가 비어 있으면 모든 pod 가 조회되므로 빈 목록 반환
+func (k *K8sClientSetHandler) getPodListBySelector(ctx context.Context, namespace, selector string) ([]*PodInfo, error) {
+	if selector == "" {
+		return []*PodInfo{}, nil
+	}
+
+	pods, err := k.clientSet.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		log.Printf("getPodListBySelector returned error: %v", err)
+		return nil, err
+	}
+
+	return createReturnPodList(pods), nil
+}
+
+func (k *K8sClientSetHandler) getCronJobSelector(ctx context.Context, cronJob *batchv1.CronJob) (string, error) {
+	jobs, err := k.clientSet.BatchV1().Jobs(cronJob.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	var names []string
+	for _, job := range jobs.Items {
+		for _, owner := range job.OwnerReferences {
+			if owner.Kind == "CronJob" && owner.Name == cronJob.Name {
+				names = append(names, job.Name)
+			}
+		}
+	}
+
+	if len(names) == 0 {
+		return "", nil
+	}
+
+	return "job-name in (" + strings.Join(names, ",") + ")", nil
+}
+
+func createWorkloadInfo(kind string, meta metav1.ObjectMeta, selector *metav1.LabelSelector, template v1.PodTemplateSpec) *WorkloadInfo {
+	return &WorkloadInfo{
+		Kind:      kind,
+		Name:      meta.Name,
+		Namespace: meta.Namespace,
+		Images:    getImages(template.Spec.Containers),
+		Age:       getAge(meta.CreationTimestamp),
+		Selector:  formatSelector(selector),
+	}
+}
+
+func getImages(containers []v1.Container) []string {
+	images := make([]string, len(containers))
+	for i, v := range containers {
+		images[i] = v.Image
+	}
+	return images
+}
+
+// kubectl 의 AGE 와 같은 형식 (ex. 5d, 3h20m)
+func getAge(creationTimestamp metav1.Time) string {
+	if creationTimestamp.IsZero() {
+		return ""
+	}
+	return duration.HumanDuration(time.Since(creationTimestamp.Time))
+}
+
+// selector 가 없거나 잘못된 경우 빈 문자열
+func formatSelector(selector *metav1.LabelSelector) string {
+	if selector == nil {
+		return ""
+	}
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil || s.Empty() {
+		return ""
+	}
+	return s.String()
+}
+
+func replicasOrDefault(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
+
+func createDeploymentInfo(d *appsv1.Deployment) *WorkloadInfo {
+	info := createWorkloadInfo("Deployment", d.ObjectMeta, d.Spec.Selector, d.Spec.Template)
+	info.Desired = replicasOrDefault(d.Spec.Replicas)
+	info.Ready = d.Status.ReadyReplicas
+	info.Updated = d.Status.UpdatedReplicas
+	info.Available = d.Status.AvailableReplicas
+	for _, v := range d.Status.Conditions {
+		info.Conditions = append(info.Conditions, &WorkloadCondition{Type: string(v.Type), Status: string(v.Status), Reason: v.Reason, Message: v.Message})
+	}
+	return info
+}
+
+func createReplicaSetInfo(r *appsv1.ReplicaSet) *WorkloadInfo {
+	info := createWorkloadInfo("ReplicaSet", r.ObjectMeta, r.Spec.Selector, r.Spec.Template)
+	info.Desired = replicasOrDefault(r.Spec.Replicas)
+	info.Ready = r.Status.ReadyReplicas
+	info.Available = r.Status.AvailableReplicas
+	for _, v := range r.Status.Conditions {
+		info.Conditions = append(info.Conditions, &WorkloadCondition{Type: string(v.Type), Status: string(v.Status), Reason: v.Reason, Message: v.Message})
+	}
+	return info
+}
+
+func createStatefulSetInfo(s *appsv1.StatefulSet) *WorkloadInfo {
+	info := createWorkloadInfo("StatefulSet", s.ObjectMeta, s.Spec.Selector, s.Spec.Template)
+	info.Desired = replicasOrDefault(s.Spec.Replicas)
+	info.Ready = s.Status.ReadyReplicas
+	info.Updated = s.Status.UpdatedReplicas
+	info.Available = s.Status.AvailableReplicas
+	for _, v := range s.Status.Conditions {
+		info.Conditions = append(info.Conditions, &WorkloadCondition{Type: string(v.Type), Status: string(v.Status), Reason: v.Reason, Message: v.Message})
+	}
+	return info
+}
+
+func createDaemonSetInfo(d *appsv1.DaemonSet) *WorkloadInfo {
+	info := createWorkloadInfo("DaemonSet", d.ObjectMeta, d.Spec.Selector, d.Spec.Template)
+	info.Desired = d.Status.DesiredNumberScheduled
+	info.Ready = d.Status.NumberReady
+	info.Updated = d.Status.UpdatedNumberScheduled
+	info.Available = d.Status.NumberAvailable
+	for _, v := range d.Status.Conditions {
+		info.Conditions = append(info.Conditions, &WorkloadCondition{Type: string(v.Type), Status: string(v.Status), Reason: v.Reason, Message: v.Message})
+	}
+	return info
+}
+
+func createJobInfo(j *batchv1.Job) *WorkloadInfo {
+	info := createWorkloadInfo("Job", j.ObjectMeta, j.Spec.Selector, j.Spec.Template)
+	info.Desired = replicasOrDefault(j.Spec.Completions)
+	if j.Status.Ready != nil {
+		info.Ready = *j.Status.Ready
+	}
+	info.Active = j.Status.Active
+	info.Succeeded = j.Status.Succeeded
+	info.Failed = j.Status.Failed
+	for _, v := range j.Status.Conditions {
+		info.Conditions = append(info.Conditions, &WorkloadCondition{Type: string(v.Type), Status: string(v.Status), Reason: v.Reason, Message: v.Message})
+	}
+	return info
+}
+
+func createCronJobInfo(c *batchv1.CronJob) *WorkloadInfo {
+	info := createWorkloadInfo("CronJob", c.ObjectMeta, nil, c.Spec.JobTemplate.Spec.Template)
+	info.Active = int32(len(c.Status.Active))
+	info.Schedule = c.Spec.Schedule
+	info.Suspend = c.Spec.Suspend != nil && *c.Spec.Suspend
+	if c.Status.LastScheduleTime != nil {
+		info.LastScheduleTime = formatTime(*c.Status.LastScheduleTime)
+	}
+	return info
+}
+
+// 지원하는 workload kind 인지 확인
+func ParseWorkloadKind(kind string) (WorkloadKind, error) {
+	for _, v := range WorkloadKinds {
+		if string(v) == strings.ToLower(kind) {
+			return v, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrUnsupportedWorkloadKind, kind)
+}
@@ -0,0 +1,145 @@
+package domain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newWorkloadTestPod(name string, labels map[string]string) *v1.Pod {
+	return &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels}}
+}
+
+func newWorkloadTestTemplate(labels map[string]string, image string) v1.PodTemplateSpec {
+	return v1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{Labels: labels},
+		Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "app", Image: image}}},
+	}
+}
+
+func TestGetWorkload(t *testing.T) {
+	assert := assert.New(t)
+
+	replicas := int32(3)
+	created := metav1.NewTime(time.Now().Add(-49 * time.Hour))
+	webLabels := map[string]string{"app": "web"}
+	dbLabels := map[string]string{"app": "db"}
+
+	clientSet := fake.NewSimpleClientset(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", CreationTimestamp: created},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: &replicas,
+				Selector: &metav1.LabelSelector{MatchLabels: webLabels},
+				Template: newWorkloadTestTemplate(webLabels, "nginx:1.23"),
+			},
+			Status: appsv1.DeploymentStatus{
+				ReadyReplicas:     2,
+				UpdatedReplicas:   3,
+				AvailableReplicas: 2,
+				Conditions: []appsv1.DeploymentCondition{
+					{Type: appsv1.DeploymentAvailable, Status: v1.ConditionFalse, Reason: "MinimumReplicasUnavailable"},
+				},
+			},
+		},
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+			Spec: appsv1.StatefulSetSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: dbLabels},
+				Template: newWorkloadTestTemplate(dbLabels, "postgres:15"),
+			},
+			Status: appsv1.StatefulSetStatus{ReadyReplicas: 1},
+		},
+		&batchv1.CronJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "default"},
+			Spec: batchv1.CronJobSpec{
+				Schedule:    "0 3 * * *",
+				JobTemplate: batchv1.JobTemplateSpec{Spec: batchv1.JobSpec{Template: newWorkloadTestTemplate(nil, "backup:1")}},
+			},
+		},
+		&batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "backup-28000000",
+				Namespace:       "default",
+				OwnerReferences: []metav1.OwnerReference{{Kind: "CronJob", Name: "backup"}},
+			},
+			Spec:   batchv1.JobSpec{Template: newWorkloadTestTemplate(nil, "backup:1")},
+			Status: batchv1.JobStatus{Succeeded: 1},
+		},
+		newWorkloadTestPod("web-1", webLabels),
+		newWorkloadTestPod("web-2", webLabels),
+		newWorkloadTestPod("db-0", dbLabels),
+		newWorkloadTestPod("backup-28000000-abcde", map[string]string{"job-name": "backup-28000000"}),
+	)
+
+	k := NewK8sClientSetHandlerWithClientSet(clientSet)
+
+	deployments, err := k.GetWorkloadList(context.Background(), Deployments, "default")
+	assert.NoError(err)
+	if assert.Equal(1, len(deployments)) {
+		d := deployments[0]
+		assert.Equal("Deployment", d.Kind)
+		assert.Equal(int32(3), d.Desired)
+		assert.Equal(int32(2), d.Ready)
+		assert.Equal(int32(3), d.Updated)
+		assert.Equal([]string{"nginx:1.23"}, d.Images)
+		assert.Equal("2d1h", d.Age)
+		assert.Equal("app=web", d.Selector)
+		assert.Equal("MinimumReplicasUnavailable", d.Conditions[0].Reason)
+	}
+
+	// 해당 kind 의 workload 가 없는 경우 빈 목록
+	daemonSets, err := k.GetWorkloadList(context.Background(), DaemonSets, "default")
+	assert.NoError(err)
+	assert.Equal(0, len(daemonSets))
+
+	detail, err := k.GetWorkload(context.Background(), Deployments, "default", "web")
+	assert.NoError(err)
+	assert.Equal(2, len(detail.Pods))
+
+	detail, err = k.GetWorkload(context.Background(), StatefulSets, "default", "db")
+	assert.NoError(err)
+	assert.Equal(int32(1), detail.Desired)
+	if assert.Equal(1, len(detail.Pods)) {
+		assert.Equal("db-0", detail.Pods[0].Name)
+	}
+
+	// cronjob 은 소유한 job 의 pod 조회
+	detail, err = k.GetWorkload(context.Background(), CronJobs, "default", "backup")
+	assert.NoError(err)
+	assert.Equal("0 3 * * *", detail.Schedule)
+	assert.Equal("job-name in (backup-28000000)", detail.Selector)
+	if assert.Equal(1, len(detail.Pods)) {
+		assert.Equal("backup-28000000-abcde", detail.Pods[0].Name)
+	}
+
+	// selector 가 없는 job 은 pod 를 조회하지 않음
+	detail, err = k.GetWorkload(context.Background(), Jobs, "default", "backup-28000000")
+	assert.NoError(err)
+	assert.Equal(int32(1), detail.Succeeded)
+	assert.Equal(0, len(detail.Pods))
+
+	_, err = k.GetWorkload(context.Background(), Deployments, "default", "unknown")
+	assert.Error(err)
+
+	_, err = k.GetWorkloadList(context.Background(), "services", "default")
+	assert.ErrorIs(err, ErrUnsupportedWorkloadKind)
+}
+
+func TestParseWorkloadKind(t *testing.T) {
+	assert := assert.New(t)
+
+	kind, err := ParseWorkloadKind("Deployments")
+	assert.NoError(err)
+	assert.Equal(Deployments, kind)
+
+	_, err = ParseWorkloadKind("pods")
+	assert.ErrorIs(err, ErrUnsupportedWorkloadKind)
+}
@@ -0,0 +1,174 @@
+package domain
+
+import (
+	"backend/config"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Shopify/sarama"
+	"github.com/xdg-go/scram"
+)
+
+// config.toml 의 [kafka] 설정으로 sarama producer 설정 생성
+func NewSaramaConfig(cfg config.Kafka) (*sarama.Config, error) {
+	c := sarama.NewConfig()
+
+	if cfg.ClientID != "" {
+		c.ClientID = cfg.ClientID
+	}
+
+	if cfg.Version != "" {
+		version, err := sarama.ParseKafkaVersion(cfg.Version)
+		if err != nil {
+			return nil, err
+		}
+		c.Version = version
+	}
+
+	acks, err := parseRequiredAcks(cfg.Acks)
+	if err != nil {
+		return nil, err
+	}
+	c.Producer.RequiredAcks = acks
+
+	if cfg.Compression != "" {
+		if err := c.Producer.Compression.UnmarshalText([]byte(cfg.Compression)); err != nil {
+			return nil, fmt.Errorf("kafka compression: %w", err)
+		}
+	}
+
+	// idempotent producer 는 acks=all, kafka 0.11 이상 필요
+	if cfg.Idempotent {
+		c.Producer.Idempotent = true
+		c.Producer.RequiredAcks = sarama.WaitForAll
+		if !c.Version.IsAtLeast(sarama.V0_11_0_0) {
+			c.Version = sarama.V0_11_0_0
+		}
+	}
+
+	// record header 는 kafka 0.11 이상 필요
+	if !c.Version.IsAtLeast(sarama.V0_11_0_0) {
+		return nil, fmt.Errorf("kafka version %s does not support record headers, 0.11.0 or later is required", c.Version)
+	}
+
+	// 같은 key 는 같은 partition 으로 전송
+	c.Producer.Partitioner = sarama.NewHashPartitioner
+	// 재전송 시에도 key 별 순서를 유지하도록 broker 당 요청은 하나씩
+	c.Net.MaxOpenRequests = 1
+	c.Producer.Return.Errors = true
+	// sync producer 는 successes 를 반드시 받아야 함
+	c.Producer.Return.Successes = !cfg.Async
+
+	if cfg.TLS.Enabled {
+		tlsConfig, err := newKafkaTLSConfig(cfg.TLS)
+		if err != nil {
+			return nil, err
+		}
+		c.Net.TLS.Enable = true
+		c.Net.TLS.Config = tlsConfig
+	}
+
+	if cfg.SASL.Enabled {
+		if err := setKafkaSASL(c, cfg.SASL); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func parseRequiredAcks(acks string) (sarama.RequiredAcks, error) {
+	switch strings.ToLower(acks) {
+	case "none", "0":
+		return sarama.NoResponse, nil
+	case "leader", "1":
+		return sarama.WaitForLocal, nil
+	case "all", "-1", "":
+		return sarama.WaitForAll, nil
+	}
+	return 0, fmt.Errorf("unknown kafka acks %q", acks)
+}
+
+func newKafkaTLSConfig(cfg config.KafkaTLS) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	}
+
+	if cfg.CAFile != "" {
+		ca, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("kafka tls: no certificate found in %s", cfg.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	// client 인증서 사용 시 cert, key 모두 필요
+	if cfg.CertFile != "" || cfg.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+func setKafkaSASL(c *sarama.Config, cfg config.KafkaSASL) error {
+	c.Net.SASL.Enable = true
+	c.Net.SASL.User = cfg.User
+	c.Net.SASL.Password = cfg.Password
+
+	switch strings.ToUpper(cfg.Mechanism) {
+	case sarama.SASLTypePlaintext:
+		c.Net.SASL.Mechanism = sarama.SASLTypePlaintext
+	case sarama.SASLTypeSCRAMSHA256:
+		c.Net.SASL.Mechanism = sarama.SASLTypeSCRAMSHA256
+		c.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient {
+			return &scramClient{HashGeneratorFcn: scram.SHA256}
+		}
+	case sarama.SASLTypeSCRAMSHA512, "":
+		c.Net.SASL.Mechanism = sarama.SASLTypeSCRAMSHA512
+		c.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient {
+			return &scramClient{HashGeneratorFcn: scram.SHA512}
+		}
+	default:
+		return fmt.Errorf("unknown kafka sasl mechanism %q", cfg.Mechanism)
+	}
+
+	return nil
+}
+
+// sarama.SCRAMClient 구현
+type scramClient struct {
+	*scram.ClientConversation
+	scram.HashGeneratorFcn
+}
+
+func (s *scramClient) Begin(userName, password, authzID string) error {
+	client, err := s.HashGeneratorFcn.NewClient(userName, password, authzID)
+	if err != nil {
+		return err
+	}
+	s.ClientConversation = client.NewConversation()
+	return nil
+}
+
+func (s *scramClient) Step(challenge string) (string, error) {
+	return s.ClientConversation.Step(challenge)
+}
+
+func (s *scramClient) Done() bool {
+	return s.ClientConversation.Done()
+}
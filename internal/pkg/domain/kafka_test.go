@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"backend/config"
+	"errors"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/Shopify/sarama/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSaramaConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	c, err := NewSaramaConfig(config.Kafka{
+		ClientID:    "test",
+		Acks:        "leader",
+		Compression: "gzip",
+		Async:       true,
+	})
+	if assert.NoError(err) {
+		assert.Equal("test", c.ClientID)
+		assert.Equal(sarama.WaitForLocal, c.Producer.RequiredAcks)
+		assert.Equal(sarama.CompressionGZIP, c.Producer.Compression)
+		assert.False(c.Producer.Return.Successes)
+		assert.True(c.Producer.Return.Errors)
+	}
+
+	// idempotent 는 acks=all 로 강제
+	c, err = NewSaramaConfig(config.Kafka{Acks: "none", Idempotent: true})
+	if assert.NoError(err) {
+		assert.True(c.Producer.Idempotent)
+		assert.Equal(sarama.WaitForAll, c.Producer.RequiredAcks)
+		assert.Equal(1, c.Net.MaxOpenRequests)
+		assert.True(c.Producer.Return.Successes)
+	}
+
+	c, err = NewSaramaConfig(config.Kafka{
+		SASL: config.KafkaSASL{Enabled: true, Mechanism: "SCRAM-SHA-256", User: "user", Password: "password"},
+	})
+	if assert.NoError(err) {
+		assert.Equal(sarama.SASLMechanism(sarama.SASLTypeSCRAMSHA256), c.Net.SASL.Mechanism)
+		assert.NoError(c.Net.SASL.SCRAMClientGeneratorFunc().Begin("user", "password", ""))
+	}
+
+	_, err = NewSaramaConfig(config.Kafka{Acks: "some"})
+	assert.Error(err)
+
+	_, err = NewSaramaConfig(config.Kafka{Compression: "brotli"})
+	assert.Error(err)
+
+	_, err = NewSaramaConfig(config.Kafka{Version: "x.y"})
+	assert.Error(err)
+
+	// record header 미지원 version
+	_, err = NewSaramaConfig(config.Kafka{Version: "0.10.2.0"})
+	assert.Error(err)
+
+	_, err = NewSaramaConfig(config.Kafka{SASL: config.KafkaSASL{Enabled: true, Mechanism: "GSSAPI"}})
+	assert.Error(err)
+
+	_, err = NewSaramaConfig(config.Kafka{TLS: config.KafkaTLS{Enabled: true, CAFile: "not-exist.pem"}})
+	assert.Error(err)
+}
+
+func TestNewSaramaConsumerConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	c, err := NewSaramaConsumerConfig(config.Kafka{Consumer: config.KafkaConsumer{InitialOffset: "oldest"}})
+	if assert.NoError(err) {
+		assert.Equal(sarama.OffsetOldest, c.Consumer.Offsets.Initial)
+		assert.False(c.Consumer.Offsets.AutoCommit.Enable)
+	}
+
+	_, err = NewSaramaConsumerConfig(config.Kafka{Consumer: config.KafkaConsumer{InitialOffset: "latest"}})
+	assert.Error(err)
+}
+
+func TestNewMessageSender(t *testing.T) {
+	assert := assert.New(t)
+
+	sender, err := NewMessageSender(config.Config{})
+	if assert.NoError(err) {
+		assert.IsType(&LogMessageSender{}, sender)
+	}
+
+	// 잘못된 설정은 시작 시 error
+	_, err = NewMessageSender(config.Config{Kafka: config.Kafka{Brokers: []string{"127.0.0.1:1"}, Acks: "some"}})
+	assert.Error(err)
+
+	// broker 연결 실패는 log sender 로 대체하지 않고 error
+	sender, err = NewMessageSender(config.Config{Kafka: config.Kafka{Brokers: []string{"127.0.0.1:1"}}})
+	assert.Error(err)
+	assert.Nil(sender)
+}
+
+func TestKafkaAsyncMessageSender(t *testing.T) {
+	assert := assert.New(t)
+
+	producer := mocks.NewAsyncProducer(t, nil)
+	producer.ExpectInputAndSucceed()
+	producer.ExpectInputAndFail(errors.New("broker down"))
+
+	sender := newKafkaAsyncMessageSender(producer)
+
+	msg := &Message{Topic: "test", Key: "key", Payload: []byte("test")}
+	assert.NoError(sender.SendMessage(msg))
+	assert.NoError(sender.SendMessage(msg))
+
+	// 종료 시 남은 오류까지 처리한 후 반환
+	assert.NoError(sender.Close())
+	assert.NoError(sender.Close())
+
+	assert.ErrorIs(sender.SendMessage(msg), ErrMessageSenderClosed)
+}
@@ -1,71 +1,124 @@
 package domain
 
 import (
+	"backend/config"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"reflect"
+	"log"
+	"sync"
 
 	"github.com/Shopify/sarama"
+	"google.golang.org/protobuf/proto"
 )
 
-type Message interface {
-	Message() map[string]interface{}
+var ErrMessageSenderClosed = errors.New("message sender is closed")
+
+const (
+	ContentTypeJSON     = "application/json"
+	ContentTypeProtobuf = "application/x-protobuf"
+
+	// payload 형식을 전달하는 kafka record header
+	contentTypeHeader = "content-type"
+)
+
+// kafka 로 전송할 message
+//
+// Key 가 같은 message 는 같은 partition 으로 전송되어 순서가 보장됨
+type Message struct {
+	Topic       string
+	Key         string
+	Headers     map[string]string
+	ContentType string
+	Payload     []byte
 }
 
 type MessageSender interface {
-	SendMessage(message Message) error
+	SendMessage(message *Message) error
 	Close() error
 }
 
-type KafkaMessage struct {
-	NewMessage string
-	Topic      string
+func NewJSONMessage(topic, key string, v interface{}) (*Message, error) {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return &Message{Topic: topic, Key: key, ContentType: ContentTypeJSON, Payload: payload}, nil
+}
+
+func NewProtoMessage(topic, key string, v proto.Message) (*Message, error) {
+	payload, err := proto.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return &Message{Topic: topic, Key: key, ContentType: ContentTypeProtobuf, Payload: payload}, nil
 }
 
-func (k *KafkaMessage) Message() map[string]interface{} {
-	m := make(map[string]interface{})
+// sarama 전송용 message 로 변환, key 가 없으면 partition 은 임의로 선택됨
+func (m *Message) producerMessage() *sarama.ProducerMessage {
+	msg := &sarama.ProducerMessage{
+		Topic: m.Topic,
+		Value: sarama.ByteEncoder(m.Payload),
+	}
 
-	rv := reflect.ValueOf(k)
+	if m.Key != "" {
+		msg.Key = sarama.StringEncoder(m.Key)
+	}
 
-	for i := 0; i < rv.NumField(); i++ {
-		m[rv.Field(i).Type().Name()] = rv.Field(i).Interface()
+	if m.ContentType != "" {
+		msg.Headers = append(msg.Headers, sarama.RecordHeader{Key: []byte(contentTypeHeader), Value: []byte(m.ContentType)})
+	}
+	for _, k := range sortedKeys(m.Headers) {
+		msg.Headers = append(msg.Headers, sarama.RecordHeader{Key: []byte(k), Value: []byte(m.Headers[k])})
 	}
 
-	return m
+	return msg
 }
 
 type KafkaMessageSender struct {
 	Sender sarama.SyncProducer
 }
 
-func NewKafkaMessageSender() MessageSender {
-	config := sarama.NewConfig()
-	config.Producer.Partitioner = sarama.NewRandomPartitioner
-	config.Producer.RequiredAcks = sarama.WaitForAll
-	config.Producer.Return.Successes = true
-	p, err := sarama.NewSyncProducer([]string{
-		"kafka-01:9092",
-		"kafka-02:9092",
-		"kafka-03:9092"}, config)
+// kafka brokers 미설정 시 log 로 출력하는 sender 반환
+//
+// brokers 가 설정되어 있으면 연결 실패는 error 로 반환하여 시작하지 않음, audit event 가 log 로만 남는 것을 막음
+func NewMessageSender(cfg config.Config) (MessageSender, error) {
+	if len(cfg.Kafka.Brokers) == 0 {
+		log.Printf("kafka brokers are not configured, messages are written to the log")
+		return &LogMessageSender{}, nil
+	}
+
+	// 설정 오류는 시작 시점에 바로 알림
+	saramaConfig, err := NewSaramaConfig(cfg.Kafka)
 	if err != nil {
-		panic(err)
+		return nil, fmt.Errorf("kafka config: %w", err)
 	}
-	return &KafkaMessageSender{Sender: p}
-}
 
-func (k *KafkaMessageSender) SendMessage(message Message) error {
+	var sender MessageSender
+	if cfg.Kafka.Async {
+		sender, err = NewKafkaAsyncMessageSender(cfg.Kafka.Brokers, saramaConfig)
+	} else {
+		sender, err = NewKafkaMessageSender(cfg.Kafka.Brokers, saramaConfig)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("connect to kafka %v: %w", cfg.Kafka.Brokers, err)
+	}
 
-	topic := fmt.Sprintf("%v", message.Message()["Topic"])
-	msg := fmt.Sprintf("%v", message.Message()["NewMessage"])
+	return sender, nil
+}
 
-	partition, offset, err := k.Sender.SendMessage(&sarama.ProducerMessage{
-		Topic: topic,
-		Value: sarama.StringEncoder(msg),
-	})
+func NewKafkaMessageSender(brokers []string, config *sarama.Config) (MessageSender, error) {
+	p, err := sarama.NewSyncProducer(brokers, config)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	fmt.Printf("%d/%d\n", partition, offset)
-	return nil
+	return &KafkaMessageSender{Sender: p}, nil
+}
+
+func (k *KafkaMessageSender) SendMessage(message *Message) error {
+
+	_, _, err := k.Sender.SendMessage(message.producerMessage())
+	return err
 }
 
 func (k *KafkaMessageSender) Close() error {
@@ -76,12 +129,82 @@ func (k *KafkaMessageSender) Close() error {
 	return nil
 }
 
-func test() {
-	ms := NewKafkaMessageSender()
-	msg := &KafkaMessage{
-		NewMessage: "test",
-		Topic:      "test",
+// 전송 결과를 기다리지 않는 sender
+//
+// 전송 오류는 background goroutine 에서 errors channel 을 읽어 log 로 출력
+type KafkaAsyncMessageSender struct {
+	Sender sarama.AsyncProducer
+
+	mu     sync.RWMutex
+	closed bool
+	done   chan struct{}
+}
+
+func NewKafkaAsyncMessageSender(brokers []string, config *sarama.Config) (MessageSender, error) {
+	p, err := sarama.NewAsyncProducer(brokers, config)
+	if err != nil {
+		return nil, err
+	}
+	return newKafkaAsyncMessageSender(p), nil
+}
+
+func newKafkaAsyncMessageSender(p sarama.AsyncProducer) *KafkaAsyncMessageSender {
+	k := &KafkaAsyncMessageSender{
+		Sender: p,
+		done:   make(chan struct{}),
+	}
+	go k.drainErrors()
+	return k
+}
+
+// producer 종료 시 errors channel 이 닫히면 반환
+func (k *KafkaAsyncMessageSender) drainErrors() {
+	defer close(k.done)
+	for err := range k.Sender.Errors() {
+		log.Printf("kafka async producer returned error: %v", err)
+	}
+}
+
+func (k *KafkaAsyncMessageSender) SendMessage(message *Message) error {
+
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	if k.closed {
+		return ErrMessageSenderClosed
+	}
+
+	k.Sender.Input() <- message.producerMessage()
+	return nil
+}
+
+// 전송 대기 중인 message 를 flush 한 후 종료
+func (k *KafkaAsyncMessageSender) Close() error {
+	k.mu.Lock()
+	if k.closed {
+		k.mu.Unlock()
+		return nil
 	}
+	k.closed = true
+	k.mu.Unlock()
+
+	k.Sender.AsyncClose()
+	<-k.done
+	return nil
+}
 
-	ms.SendMessage(msg)
+// kafka 없이 실행할 때 사용
+type LogMessageSender struct{}
+
+func (l *LogMessageSender) SendMessage(message *Message) error {
+	if message.ContentType == ContentTypeJSON {
+		log.Printf("[%s] key=%s %s", message.Topic, message.Key, message.Payload)
+		return nil
+	}
+	log.Printf("[%s] key=%s %s (%d bytes)", message.Topic, message.Key, message.ContentType, len(message.Payload))
+	return nil
+}
+
+func (l *LogMessageSender) Close() error {
+	return nil
 }
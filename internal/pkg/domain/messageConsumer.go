@@ -0,0 +1,315 @@
+package domain
+
+import (
+	"backend/config"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Shopify/sarama"
+)
+
+// 재시도해도 성공할 수 없는 message, 재시도 없이 dead-letter topic 으로 전송
+var ErrInvalidMessage = errors.New("invalid message")
+
+// dead-letter message 에 추가되는 header
+const (
+	deadLetterErrorHeader     = "dlq-error"
+	deadLetterTopicHeader     = "dlq-original-topic"
+	deadLetterPartitionHeader = "dlq-original-partition"
+	deadLetterOffsetHeader    = "dlq-original-offset"
+)
+
+// error 를 반환하면 재시도 후 dead-letter topic 으로 전송
+type MessageHandler func(ctx context.Context, message *Message) error
+
+type MessageConsumer interface {
+	// Start 전에 등록한 topic 만 구독
+	Handle(topic string, handler MessageHandler)
+	Start() error
+	Close() error
+}
+
+// consumer 미사용 시 handler 등록만 받고 구독하지 않음
+type nopMessageConsumer struct{}
+
+func (n *nopMessageConsumer) Handle(topic string, handler MessageHandler) {}
+func (n *nopMessageConsumer) Start() error                                { return nil }
+func (n *nopMessageConsumer) Close() error                                { return nil }
+
+func NewMessageConsumer(cfg config.Config, sender MessageSender) (MessageConsumer, error) {
+	if !cfg.Kafka.Consumer.Enabled || len(cfg.Kafka.Brokers) == 0 {
+		return &nopMessageConsumer{}, nil
+	}
+
+	saramaConfig, err := NewSaramaConsumerConfig(cfg.Kafka)
+	if err != nil {
+		return nil, fmt.Errorf("kafka consumer config: %w", err)
+	}
+
+	group, err := sarama.NewConsumerGroup(cfg.Kafka.Brokers, cfg.Kafka.Consumer.GroupID, saramaConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewKafkaMessageConsumer(group, sender, cfg.Kafka.Consumer), nil
+}
+
+// producer 설정에 consumer group 설정 추가, offset 은 handler 처리 후 직접 commit
+func NewSaramaConsumerConfig(cfg config.Kafka) (*sarama.Config, error) {
+	c, err := NewSaramaConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	switch cfg.Consumer.InitialOffset {
+	case "oldest":
+		c.Consumer.Offsets.Initial = sarama.OffsetOldest
+	case "newest", "":
+		c.Consumer.Offsets.Initial = sarama.OffsetNewest
+	default:
+		return nil, fmt.Errorf("unknown kafka initialOffset %q", cfg.Consumer.InitialOffset)
+	}
+	c.Consumer.Offsets.AutoCommit.Enable = false
+	c.Consumer.Return.Errors = true
+
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+type KafkaMessageConsumer struct {
+	group  sarama.ConsumerGroup
+	sender MessageSender
+
+	maxRetries       int
+	retryBackoff     time.Duration
+	deadLetterSuffix string
+
+	mu       sync.Mutex
+	handlers map[string]MessageHandler
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+func NewKafkaMessageConsumer(group sarama.ConsumerGroup, sender MessageSender, cfg config.KafkaConsumer) *KafkaMessageConsumer {
+	return &KafkaMessageConsumer{
+		group:            group,
+		sender:           sender,
+		maxRetries:       cfg.MaxRetries,
+		retryBackoff:     cfg.RetryBackoff,
+		deadLetterSuffix: cfg.DeadLetterSuffix,
+		handlers:         make(map[string]MessageHandler),
+	}
+}
+
+func (k *KafkaMessageConsumer) Handle(topic string, handler MessageHandler) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.handlers[topic] = handler
+}
+
+// Consume 실패 시 최대 재시도 간격
+const maxConsumeBackoff = 30 * time.Second
+
+// Consume 실패 후 첫 재시도 간격, retryBackoff 미설정 시 1초
+func (k *KafkaMessageConsumer) consumeBackoff() time.Duration {
+	if k.retryBackoff <= 0 {
+		return time.Second
+	}
+	return k.retryBackoff
+}
+
+// rebalance 후에도 계속 구독하도록 Close 전까지 Consume 반복
+func (k *KafkaMessageConsumer) Start() error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if k.cancel != nil {
+		return nil
+	}
+
+	topics := make([]string, 0, len(k.handlers))
+	for topic := range k.handlers {
+		topics = append(topics, topic)
+	}
+	if len(topics) == 0 {
+		log.Printf("kafka consumer has no handler, nothing to consume")
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	k.cancel = cancel
+	k.done = make(chan struct{})
+
+	go func() {
+		defer close(k.done)
+		backoff := k.consumeBackoff()
+		for {
+			err := k.group.Consume(ctx, topics, k)
+			if ctx.Err() != nil {
+				return
+			}
+			if err == nil {
+				backoff = k.consumeBackoff()
+				continue
+			}
+
+			if errors.Is(err, sarama.ErrClosedConsumerGroup) {
+				log.Printf("kafka consumer returned error: %v", err)
+				return
+			}
+			log.Printf("kafka consumer returned error: %v, retry in %s", err, backoff)
+
+			// broker 연결 실패 등은 대기 후 재시도
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+			if backoff *= 2; backoff > maxConsumeBackoff {
+				backoff = maxConsumeBackoff
+			}
+		}
+	}()
+
+	go func() {
+		for err := range k.group.Errors() {
+			log.Printf("kafka consumer group returned error: %v", err)
+		}
+	}()
+
+	return nil
+}
+
+func (k *KafkaMessageConsumer) Close() error {
+	k.mu.Lock()
+	cancel, done := k.cancel, k.done
+	k.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+		<-done
+	}
+
+	return k.group.Close()
+}
+
+// sarama.ConsumerGroupHandler 구현
+func (k *KafkaMessageConsumer) Setup(sarama.ConsumerGroupSession) error   { return nil }
+func (k *KafkaMessageConsumer) Cleanup(sarama.ConsumerGroupSession) error { return nil }
+
+// 처리 완료(성공 또는 dead-letter 전송) 후에만 offset commit
+func (k *KafkaMessageConsumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	for {
+		select {
+		case msg, ok := <-claim.Messages():
+			if !ok {
+				return nil
+			}
+			if err := k.handleMessage(session.Context(), msg); err != nil {
+				// commit 하지 않고 종료하여 다음 session 에서 다시 처리
+				return err
+			}
+			session.MarkMessage(msg, "")
+			session.Commit()
+		case <-session.Context().Done():
+			return nil
+		}
+	}
+}
+
+// handler 실패 시 재시도 후 dead-letter topic 으로 전송
+//
+// dead-letter 전송까지 실패했거나 종료 중(ctx 취소)이면 error 반환, commit 하지 않아 다시 처리됨
+func (k *KafkaMessageConsumer) handleMessage(ctx context.Context, msg *sarama.ConsumerMessage) error {
+	k.mu.Lock()
+	handler, ok := k.handlers[msg.Topic]
+	k.mu.Unlock()
+
+	if !ok {
+		log.Printf("kafka message on %s has no handler, skipped", msg.Topic)
+		return nil
+	}
+
+	message := messageFromConsumer(msg)
+
+	var err error
+	for attempt := 0; attempt <= k.maxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(k.retryBackoff):
+			}
+		}
+
+		if err = handler(ctx, message); err == nil {
+			return nil
+		}
+		log.Printf("kafka message %s/%d/%d handler returned error: %v", msg.Topic, msg.Partition, msg.Offset, err)
+
+		if errors.Is(err, ErrInvalidMessage) {
+			break
+		}
+	}
+
+	// 종료로 중단된 처리는 실패가 아니므로 dead-letter 로 보내지 않음
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	return k.sendDeadLetter(msg, message, err)
+}
+
+func (k *KafkaMessageConsumer) sendDeadLetter(msg *sarama.ConsumerMessage, message *Message, cause error) error {
+	deadLetter := &Message{
+		Topic:       msg.Topic + k.deadLetterSuffix,
+		Key:         message.Key,
+		ContentType: message.ContentType,
+		Payload:     message.Payload,
+		Headers:     make(map[string]string, len(message.Headers)+4),
+	}
+	for key, value := range message.Headers {
+		deadLetter.Headers[key] = value
+	}
+	deadLetter.Headers[deadLetterErrorHeader] = cause.Error()
+	deadLetter.Headers[deadLetterTopicHeader] = msg.Topic
+	deadLetter.Headers[deadLetterPartitionHeader] = strconv.FormatInt(int64(msg.Partition), 10)
+	deadLetter.Headers[deadLetterOffsetHeader] = strconv.FormatInt(msg.Offset, 10)
+
+	if err := k.sender.SendMessage(deadLetter); err != nil {
+		return fmt.Errorf("dead-letter message to %s: %w", deadLetter.Topic, err)
+	}
+
+	return nil
+}
+
+func messageFromConsumer(msg *sarama.ConsumerMessage) *Message {
+	message := &Message{
+		Topic:   msg.Topic,
+		Key:     string(msg.Key),
+		Payload: msg.Value,
+		Headers: make(map[string]string),
+	}
+
+	for _, header := range msg.Headers {
+		if header == nil {
+			continue
+		}
+		if string(header.Key) == contentTypeHeader {
+			message.ContentType = string(header.Value)
+			continue
+		}
+		message.Headers[string(header.Key)] = string(header.Value)
+	}
+
+	return message
+}
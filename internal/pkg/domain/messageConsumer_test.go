@@ -0,0 +1,238 @@
+package domain
+
+import (
+	"backend/config"
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+)
+
+type testConsumerGroupSession struct {
+	ctx       context.Context
+	marked    []int64
+	committed int
+}
+
+func (s *testConsumerGroupSession) Claims() map[string][]int32 { return nil }
+func (s *testConsumerGroupSession) MemberID() string           { return "test" }
+func (s *testConsumerGroupSession) GenerationID() int32        { return 1 }
+func (s *testConsumerGroupSession) MarkOffset(topic string, partition int32, offset int64, metadata string) {
+}
+func (s *testConsumerGroupSession) ResetOffset(topic string, partition int32, offset int64, metadata string) {
+}
+func (s *testConsumerGroupSession) MarkMessage(msg *sarama.ConsumerMessage, metadata string) {
+	s.marked = append(s.marked, msg.Offset)
+}
+func (s *testConsumerGroupSession) Commit()                  { s.committed++ }
+func (s *testConsumerGroupSession) Context() context.Context { return s.ctx }
+
+type testConsumerGroupClaim struct {
+	messages chan *sarama.ConsumerMessage
+}
+
+func (c *testConsumerGroupClaim) Topic() string                            { return "alerts" }
+func (c *testConsumerGroupClaim) Partition() int32                         { return 0 }
+func (c *testConsumerGroupClaim) InitialOffset() int64                     { return 0 }
+func (c *testConsumerGroupClaim) HighWaterMarkOffset() int64               { return 0 }
+func (c *testConsumerGroupClaim) Messages() <-chan *sarama.ConsumerMessage { return c.messages }
+
+// dead-letter 전송 실패용 sender
+type failingMessageSender struct{}
+
+func (f *failingMessageSender) SendMessage(message *Message) error { return errors.New("broker down") }
+func (f *failingMessageSender) Close() error                       { return nil }
+
+// Consume 이 항상 실패하는 consumer group
+type failingConsumerGroup struct {
+	sarama.ConsumerGroup
+	calls int32
+}
+
+func (f *failingConsumerGroup) Consume(ctx context.Context, topics []string, handler sarama.ConsumerGroupHandler) error {
+	atomic.AddInt32(&f.calls, 1)
+	return errors.New("broker down")
+}
+func (f *failingConsumerGroup) Errors() <-chan error { return make(chan error) }
+func (f *failingConsumerGroup) Close() error         { return nil }
+
+func newTestConsumerMessage(offset int64, value string) *sarama.ConsumerMessage {
+	return &sarama.ConsumerMessage{
+		Topic:     "alerts",
+		Partition: 0,
+		Offset:    offset,
+		Key:       []byte("key"),
+		Value:     []byte(value),
+		Headers: []*sarama.RecordHeader{
+			{Key: []byte(contentTypeHeader), Value: []byte(ContentTypeJSON)},
+			{Key: []byte("trace-id"), Value: []byte("abc")},
+		},
+	}
+}
+
+func TestKafkaMessageConsumer(t *testing.T) {
+	assert := assert.New(t)
+
+	sender := &testMessageSender{}
+	consumer := NewKafkaMessageConsumer(nil, sender, config.KafkaConsumer{MaxRetries: 2, DeadLetterSuffix: ".dlq"})
+
+	calls := map[string]int{}
+	consumer.Handle("alerts", func(ctx context.Context, message *Message) error {
+		calls[string(message.Payload)]++
+		assert.Equal(ContentTypeJSON, message.ContentType)
+		assert.Equal("abc", message.Headers["trace-id"])
+
+		switch string(message.Payload) {
+		case "fail":
+			return errors.New("temporary")
+		case "invalid":
+			return ErrInvalidMessage
+		}
+		return nil
+	})
+
+	claim := &testConsumerGroupClaim{messages: make(chan *sarama.ConsumerMessage, 3)}
+	claim.messages <- newTestConsumerMessage(1, "ok")
+	claim.messages <- newTestConsumerMessage(2, "fail")
+	claim.messages <- newTestConsumerMessage(3, "invalid")
+	close(claim.messages)
+
+	session := &testConsumerGroupSession{ctx: context.Background()}
+	assert.NoError(consumer.ConsumeClaim(session, claim))
+
+	// 성공 및 dead-letter 전송 후 모두 commit
+	assert.Equal([]int64{1, 2, 3}, session.marked)
+	assert.Equal(3, session.committed)
+
+	// 재시도 횟수, invalid message 는 재시도 없음
+	assert.Equal(1, calls["ok"])
+	assert.Equal(3, calls["fail"])
+	assert.Equal(1, calls["invalid"])
+
+	if assert.Equal(2, len(sender.messages)) {
+		deadLetter := sender.messages[0]
+		assert.Equal("alerts.dlq", deadLetter.Topic)
+		assert.Equal("key", deadLetter.Key)
+		assert.Equal("fail", string(deadLetter.Payload))
+		assert.Equal(ContentTypeJSON, deadLetter.ContentType)
+		assert.Equal("temporary", deadLetter.Headers[deadLetterErrorHeader])
+		assert.Equal("alerts", deadLetter.Headers[deadLetterTopicHeader])
+		assert.Equal("2", deadLetter.Headers[deadLetterOffsetHeader])
+		assert.Equal("abc", deadLetter.Headers["trace-id"])
+	}
+}
+
+func TestKafkaMessageConsumerDeadLetterFailure(t *testing.T) {
+	assert := assert.New(t)
+
+	consumer := NewKafkaMessageConsumer(nil, &failingMessageSender{}, config.KafkaConsumer{DeadLetterSuffix: ".dlq"})
+	consumer.Handle("alerts", func(ctx context.Context, message *Message) error {
+		return errors.New("temporary")
+	})
+
+	claim := &testConsumerGroupClaim{messages: make(chan *sarama.ConsumerMessage, 1)}
+	claim.messages <- newTestConsumerMessage(1, "fail")
+
+	// dead-letter 전송까지 실패하면 commit 하지 않음
+	session := &testConsumerGroupSession{ctx: context.Background()}
+	assert.Error(consumer.ConsumeClaim(session, claim))
+	assert.Empty(session.marked)
+	assert.Equal(0, session.committed)
+}
+
+func TestKafkaMessageConsumerShutdown(t *testing.T) {
+	assert := assert.New(t)
+
+	sender := &testMessageSender{}
+	consumer := NewKafkaMessageConsumer(nil, sender, config.KafkaConsumer{DeadLetterSuffix: ".dlq"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	consumer.Handle("alerts", func(ctx context.Context, message *Message) error {
+		// 마지막 시도 중 종료
+		cancel()
+		return ctx.Err()
+	})
+
+	claim := &testConsumerGroupClaim{messages: make(chan *sarama.ConsumerMessage, 1)}
+	claim.messages <- newTestConsumerMessage(1, "ok")
+
+	// dead-letter 로 보내지 않고 commit 없이 종료하여 재시작 후 다시 처리
+	session := &testConsumerGroupSession{ctx: ctx}
+	assert.ErrorIs(consumer.ConsumeClaim(session, claim), context.Canceled)
+	assert.Empty(sender.messages)
+	assert.Empty(session.marked)
+	assert.Equal(0, session.committed)
+}
+
+func TestKafkaMessageConsumerBackoff(t *testing.T) {
+	assert := assert.New(t)
+
+	group := &failingConsumerGroup{}
+	consumer := NewKafkaMessageConsumer(group, &LogMessageSender{}, config.KafkaConsumer{RetryBackoff: 20 * time.Millisecond})
+	consumer.Handle("alerts", func(ctx context.Context, message *Message) error { return nil })
+	assert.NoError(consumer.Start())
+
+	// 20ms, 40ms, 80ms 간격으로 재시도
+	time.Sleep(100 * time.Millisecond)
+
+	// 대기 중에도 Close 하면 바로 종료
+	start := time.Now()
+	assert.NoError(consumer.Close())
+	assert.Less(time.Since(start), time.Second)
+
+	calls := atomic.LoadInt32(&group.calls)
+	assert.GreaterOrEqual(calls, int32(2))
+	assert.LessOrEqual(calls, int32(5))
+}
+
+type alertGitClient struct {
+	GitClientHandler
+	owner, repo string
+	issue       *CreateGitIssueRequest
+}
+
+func (a *alertGitClient) CreateIssue(ctx context.Context, owner, repo string, issueRequest *CreateGitIssueRequest) (*GitIssue, error) {
+	a.owner, a.repo, a.issue = owner, repo, issueRequest
+	return &GitIssue{Title: issueRequest.Title, Owner: owner, Repo: repo}, nil
+}
+
+func TestAlertIssueHandler(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &alertGitClient{}
+	handler := NewAlertIssueHandler(client, "jaemocho", "alerts")
+	ctx := context.Background()
+
+	message, _ := NewJSONMessage("alerts", "", &AlertMessage{
+		Title:       "pod crash looping",
+		Description: "nginx restarted 5 times",
+		Severity:    "critical",
+		Source:      "prometheus",
+	})
+	assert.NoError(handler(ctx, message))
+	assert.Equal("jaemocho", client.owner)
+	assert.Equal("alerts", client.repo)
+	assert.Equal("[CRITICAL] pod crash looping", client.issue.Title)
+	assert.Equal("nginx restarted 5 times\n\nsource: prometheus", client.issue.Body)
+	assert.Equal([]string{"alert", "severity:critical"}, client.issue.Labels)
+
+	// message 의 owner, repo 는 무시하고 설정된 repo 사용
+	message = &Message{Payload: []byte(`{"title":"disk full","owner":"team","repo":"infra"}`)}
+	assert.NoError(handler(ctx, message))
+	assert.Equal("jaemocho", client.owner)
+	assert.Equal("alerts", client.repo)
+
+	// 설정된 repo 가 없으면 재시도 없이 dead-letter
+	message, _ = NewJSONMessage("alerts", "", &AlertMessage{Title: "disk full"})
+	assert.ErrorIs(NewAlertIssueHandler(client, "", "")(ctx, message), ErrInvalidMessage)
+
+	err := handler(ctx, &Message{Payload: []byte("not json")})
+	assert.ErrorIs(err, ErrInvalidMessage)
+
+	message, _ = NewJSONMessage("alerts", "", &AlertMessage{Description: "no title"})
+	assert.ErrorIs(handler(ctx, message), ErrInvalidMessage)
+}
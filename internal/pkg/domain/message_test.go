@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"backend/config"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestNewMessage(t *testing.T) {
+	assert := assert.New(t)
+
+	msg, err := NewJSONMessage("audit", "user/1", map[string]string{"action": "user.create"})
+	if assert.NoError(err) {
+		assert.Equal(ContentTypeJSON, msg.ContentType)
+		assert.JSONEq(`{"action":"user.create"}`, string(msg.Payload))
+	}
+
+	msg, err = NewProtoMessage("audit", "user/1", wrapperspb.String("user.create"))
+	if assert.NoError(err) {
+		assert.Equal(ContentTypeProtobuf, msg.ContentType)
+
+		value := &wrapperspb.StringValue{}
+		assert.NoError(proto.Unmarshal(msg.Payload, value))
+		assert.Equal("user.create", value.GetValue())
+	}
+
+	// header 는 content-type 다음 key 순서
+	msg.Headers = map[string]string{"trace-id": "abc", "source": "backend"}
+	producerMessage := msg.producerMessage()
+
+	key, _ := producerMessage.Key.Encode()
+	assert.Equal("user/1", string(key))
+	if assert.Equal(3, len(producerMessage.Headers)) {
+		assert.Equal("content-type", string(producerMessage.Headers[0].Key))
+		assert.Equal(ContentTypeProtobuf, string(producerMessage.Headers[0].Value))
+		assert.Equal("source", string(producerMessage.Headers[1].Key))
+		assert.Equal("trace-id", string(producerMessage.Headers[2].Key))
+	}
+
+	// key 가 없으면 nil key
+	assert.Nil((&Message{Topic: "audit"}).producerMessage().Key)
+}
+
+func TestMessagePartitionByKey(t *testing.T) {
+	assert := assert.New(t)
+
+	c, err := NewSaramaConfig(config.Kafka{})
+	if !assert.NoError(err) {
+		return
+	}
+	assert.Equal(1, c.Net.MaxOpenRequests)
+
+	// producer 를 새로 만들어도 같은 key 는 같은 partition
+	first := c.Producer.Partitioner("audit")
+	second := c.Producer.Partitioner("audit")
+	assert.True(first.RequiresConsistency())
+
+	for _, key := range []string{"user/1", "user/2", "dev/default/deployments/nginx"} {
+		msg := &Message{Topic: "audit", Key: key}
+
+		expected, err := first.Partition(msg.producerMessage(), 12)
+		assert.NoError(err)
+
+		for i := 0; i < 10; i++ {
+			partition, _ := second.Partition(msg.producerMessage(), 12)
+			assert.Equal(expected, partition)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package http
 import (
 	"backend/config"
 	"backend/internal/pkg/domain"
+	"backend/internal/pkg/security"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
@@ -10,23 +11,27 @@ import (
 
 type GitHandler struct {
 	client domain.GitClientHandler
+	audit  *domain.AuditPublisher
 }
 
-func NewGitHandler(echo *echo.Echo, cfg config.Config) *GitHandler {
+func NewGitHandler(echo *echo.Echo, cfg config.Config, audit *domain.AuditPublisher) *GitHandler {
 
 	handler := &GitHandler{
 		client: domain.NewGitClientHandler(cfg),
+		audit:  audit,
 	}
 
-	gitClient := echo.Group("/api/v1/github")
+	gitClient := echo.Group("/api/v1/github", security.RateLimit(cfg, "github"))
 	{
-		gitClient.GET("/:owner", handler.getReposByOwner)
-		gitClient.POST("/:owner", handler.createRepo)
-		gitClient.GET("/:owner/:repo", handler.getWorkflowsByRepo)
-		gitClient.DELETE("/:owner/:repo", handler.deleteRepo)
+		gitClient.GET("/:owner", handler.getReposByOwner, security.RequirePermission(security.PermRepoRead))
+		gitClient.POST("/:owner", handler.createRepo, security.RequirePermission(security.PermRepoWrite))
+		gitClient.GET("/:owner/:repo", handler.getWorkflowsByRepo, security.RequirePermission(security.PermRepoRead))
+		gitClient.DELETE("/:owner/:repo", handler.deleteRepo, security.RequirePermission(security.PermRepoDelete))
 
-		gitClient.POST("/issue/:owner/:repo", handler.createIssue)
-		gitClient.GET("/issue/:owner/:repo", handler.getIssuesByRepo)
+		gitClient.POST("/issue/:owner/:repo", handler.createIssue, security.RequirePermission(security.PermIssueWrite))
+		gitClient.GET("/issue/:owner/:repo", handler.getIssuesByRepo, security.RequirePermission(security.PermRepoRead))
+
+		gitClient.POST("/workflow/:owner/:repo/:workflow", handler.dispatchWorkflow, security.RequirePermission(security.PermWorkflowDispatch))
 	}
 
 	return handler
@@ -45,7 +50,7 @@ func (g *GitHandler) getReposByOwner(c echo.Context) error {
 
 	owner := c.Param("owner")
 
-	repos, err := g.client.GetRepoList(owner)
+	repos, err := g.client.GetRepoList(c.Request().Context(), owner)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, nil)
 	}
@@ -68,7 +73,7 @@ func (g *GitHandler) getWorkflowsByRepo(c echo.Context) error {
 	owner := c.Param("owner")
 	repo := c.Param("repo")
 
-	workflows, err := g.client.GetWorkflowList(owner, repo)
+	workflows, err := g.client.GetWorkflowList(c.Request().Context(), owner, repo)
 
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, nil)
@@ -96,7 +101,8 @@ func (g *GitHandler) createRepo(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, err)
 	}
 
-	repo, err := g.client.CreateRepo(createGitRepoRequest)
+	repo, err := g.client.CreateRepo(c.Request().Context(), createGitRepoRequest)
+	g.audit.Publish(security.Actor(c), "repo.create", c.Param("owner")+"/"+createGitRepoRequest.Name, err)
 
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, err)
@@ -121,7 +127,8 @@ func (g *GitHandler) deleteRepo(c echo.Context) error {
 	owner := c.Param("owner")
 	repo := c.Param("repo")
 
-	err := g.client.DeleteRepo(owner, repo)
+	err := g.client.DeleteRepo(c.Request().Context(), owner, repo)
+	g.audit.Publish(security.Actor(c), "repo.delete", owner+"/"+repo, err)
 
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, err)
@@ -154,7 +161,8 @@ func (g *GitHandler) createIssue(c echo.Context) error {
 	owner := c.Param("owner")
 	repo := c.Param("repo")
 
-	newIssue, err := g.client.CreateIssue(owner, repo, gitIssue)
+	newIssue, err := g.client.CreateIssue(c.Request().Context(), owner, repo, gitIssue)
+	g.audit.Publish(security.Actor(c), "issue.create", owner+"/"+repo, err)
 
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, err)
@@ -179,10 +187,49 @@ func (g *GitHandler) getIssuesByRepo(c echo.Context) error {
 	owner := c.Param("owner")
 	repo := c.Param("repo")
 
-	issues, err := g.client.GetIssueList(owner, repo)
+	issues, err := g.client.GetIssueList(c.Request().Context(), owner, repo)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, nil)
 	}
 
 	return c.JSON(http.StatusOK, issues)
 }
+
+// @Summary		Dispatch workflow
+// @Description	Trigger workflow_dispatch event of the workflow file
+// @name		dispatchWorkflow
+// @Accept		json
+// @Produce		json
+// @Param		owner		path	string	true	"owner of the repo"
+// @Param		repo		path	string	true	"repo"
+// @Param		workflow	path	string	true	"workflow file name (e.g. build.yml)"
+// @Param		dispatch	body	domain.CreateWorkflowDispatchRequest	true	"Dispatch Info body"
+// @Success		201		{object} string
+// @Router		/api/v1/github/workflow/{owner}/{repo}/{workflow} [post]
+// @Security	ApiKeyAuth
+func (g *GitHandler) dispatchWorkflow(c echo.Context) error {
+
+	dispatch := new(domain.CreateWorkflowDispatchRequest)
+
+	if err := c.Bind(dispatch); err != nil {
+		c.Error(err)
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	if dispatch.Ref == "" {
+		return c.JSON(http.StatusBadRequest, "ref is required")
+	}
+
+	owner := c.Param("owner")
+	repo := c.Param("repo")
+	workflow := c.Param("workflow")
+
+	err := g.client.CreateWorkflowDispatchEventByFileName(c.Request().Context(), owner, repo, workflow, dispatch.Ref, dispatch.Inputs)
+	g.audit.Publish(security.Actor(c), "workflow.dispatch", owner+"/"+repo+"/"+workflow+"@"+dispatch.Ref, err)
+
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusCreated, workflow+" dispatch success")
+}
@@ -18,13 +18,14 @@ var (
 	cfg = config.Config{
 		GitHubToken: "",
 	}
+	audit = domain.NewAuditPublisher(&domain.LogMessageSender{}, cfg)
 )
 
 func TestGetRepos(t *testing.T) {
 
 	e := echo.New()
 
-	gh := NewGitHandler(e, cfg)
+	gh := NewGitHandler(e, cfg, audit)
 
 	// 1. 조회 테스트 reop
 	req := httptest.NewRequest(http.MethodGet, "/", nil)
@@ -56,7 +57,7 @@ func TestGetWorkflows(t *testing.T) {
 
 	e := echo.New()
 
-	gh := NewGitHandler(e, cfg)
+	gh := NewGitHandler(e, cfg, audit)
 
 	// 1. 조회 테스트 reop
 	req := httptest.NewRequest(http.MethodGet, "/", nil)
@@ -88,7 +89,7 @@ func TestCreateRepo(t *testing.T) {
 
 	e := echo.New()
 
-	gh := NewGitHandler(e, cfg)
+	gh := NewGitHandler(e, cfg, audit)
 
 	body, _ := json.Marshal(&domain.CreateGitRepoRequest{Name: "maketest123", Description: "create test", IsPrivate: false, IsAutoInt: false})
 
@@ -114,7 +115,7 @@ func TestDeleteRepo(t *testing.T) {
 
 	e := echo.New()
 
-	gh := NewGitHandler(e, cfg)
+	gh := NewGitHandler(e, cfg, audit)
 
 	// 1. repo 삭제 테스트
 	req := httptest.NewRequest(http.MethodDelete, "/", nil)
@@ -135,7 +136,7 @@ func TestDeleteRepo(t *testing.T) {
 func TestCreateIssue(t *testing.T) {
 	e := echo.New()
 
-	gh := NewGitHandler(e, cfg)
+	gh := NewGitHandler(e, cfg, audit)
 
 	title := "test"
 	issueBody := "test body"
@@ -0,0 +1,706 @@
+package http
+
+import (
+	"backend/config"
+	"backend/internal/pkg/domain"
+	"backend/internal/pkg/security"
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+type K8sHandler struct {
+	clusters *domain.K8sClusterRegistry
+	audit    *domain.AuditPublisher
+}
+
+func NewK8sHandler(echo *echo.Echo, cfg config.Config, audit *domain.AuditPublisher) *K8sHandler {
+
+	handler := &K8sHandler{
+		clusters: domain.NewK8sClusterRegistry(cfg),
+		audit:    audit,
+	}
+
+	read := security.RequirePermission(security.PermK8sRead)
+	write := security.RequirePermission(security.PermK8sWrite)
+
+	k8s := echo.Group("/api/v1/k8s", security.RateLimit(cfg, "k8s"))
+	{
+		k8s.GET("/clusters", handler.getClusters, read)
+		k8s.GET("/:namespace/pods", handler.getPodList, read)
+		k8s.GET("/:namespace/pods/:pod/events", handler.getPodEvents, read)
+		k8s.GET("/:namespace/pods/:pod/logs", handler.getPodLogs, read)
+		k8s.GET("/:namespace/pods/:pod/logs/stream", handler.streamPodLogs, read)
+		k8s.GET("/:namespace/pods/:pod/desc", handler.getPodDesc, read)
+
+		k8s.GET("/:namespace/workloads/:kind", handler.getWorkloadList, read)
+		k8s.GET("/:namespace/workloads/:kind/:name", handler.getWorkload, read)
+
+		k8s.PUT("/:namespace/workloads/:kind/:name/scale", handler.scaleWorkload, write)
+		k8s.POST("/:namespace/workloads/:kind/:name/restart", handler.restartWorkload, write)
+		k8s.POST("/:namespace/workloads/:kind/:name/rollback", handler.rollbackDeployment, write)
+		k8s.POST("/:namespace/workloads/:kind/:name/pause", handler.pauseDeployment, write)
+		k8s.POST("/:namespace/workloads/:kind/:name/resume", handler.resumeDeployment, write)
+		k8s.GET("/:namespace/workloads/:kind/:name/rollout-status", handler.getRolloutStatus, read)
+	}
+
+	return handler
+}
+
+// cluster query param 으로 대상 cluster 선택, 없으면 default cluster
+func (k *K8sHandler) client(c echo.Context) (domain.K8sClientHandler, error) {
+	return k.clusters.Client(c.QueryParam("cluster"))
+}
+
+// 없는 cluster 는 404, kubeconfig 오류로 client 생성 실패 시 503
+func clusterErrorStatus(err error) int {
+	if errors.Is(err, domain.ErrClusterNotFound) {
+		return http.StatusNotFound
+	}
+	return http.StatusServiceUnavailable
+}
+
+// k8s api 의 NotFound 는 404, 호출 제한 시간 초과는 504, 나머지는 500 으로 반환
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	case apierrors.IsNotFound(err), errors.Is(err, domain.ErrRevisionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrUnsupportedWorkloadKind):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrDeploymentPaused), apierrors.IsConflict(err):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
+// query param 이 없으면 defaultValue 반환
+func boolQueryParam(c echo.Context, name string, defaultValue bool) (bool, error) {
+	param := c.QueryParam(name)
+	if param == "" {
+		return defaultValue, nil
+	}
+	return strconv.ParseBool(param)
+}
+
+// query param 이 없으면 nil 반환, 음수는 허용하지 않음
+func int64QueryParam(c echo.Context, name string) (*int64, error) {
+	param := c.QueryParam(name)
+	if param == "" {
+		return nil, nil
+	}
+	value, err := strconv.ParseInt(param, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	if value < 0 {
+		return nil, fmt.Errorf("%s must not be negative", name)
+	}
+	return &value, nil
+}
+
+// @Summary		Get clusters
+// @Description	Get configured k8s clusters and check that each one is reachable
+// @name		getClusters
+// @Accept		json
+// @Produce		json
+// @Success		200	{array}	domain.ClusterStatus
+// @Router		/api/v1/k8s/clusters [get]
+// @Security    ApiKeyAuth
+func (k *K8sHandler) getClusters(c echo.Context) error {
+
+	statuses := k.clusters.CheckClusters(c.Request().Context())
+
+	return c.JSON(http.StatusOK, statuses)
+}
+
+// @Summary		Get pods
+// @Description	Get pod list by namespace
+// @name		getPodList
+// @Accept		json
+// @Produce		json
+// @Param		namespace	path	string	true	"namespace of the pods"
+// @Param		cluster		query	string	false	"cluster name (default cluster if empty)"
+// @Success		200			{array}	domain.PodInfo
+// @Router		/api/v1/k8s/{namespace}/pods [get]
+// @Security    ApiKeyAuth
+func (k *K8sHandler) getPodList(c echo.Context) error {
+
+	namespace := c.Param("namespace")
+
+	client, err := k.client(c)
+	if err != nil {
+		return c.JSON(clusterErrorStatus(err), err.Error())
+	}
+
+	pods, err := client.GetPodList(c.Request().Context(), namespace)
+	if err != nil {
+		return c.JSON(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, pods)
+}
+
+// @Summary		Get pod events
+// @Description	Get events of the pod
+// @name		getPodEvents
+// @Accept		json
+// @Produce		json
+// @Param		namespace	path	string	true	"namespace of the pod"
+// @Param		pod			path	string	true	"name of the pod"
+// @Param		cluster		query	string	false	"cluster name (default cluster if empty)"
+// @Success		200			{array}	domain.PodEvent
+// @Router		/api/v1/k8s/{namespace}/pods/{pod}/events [get]
+// @Security    ApiKeyAuth
+func (k *K8sHandler) getPodEvents(c echo.Context) error {
+
+	namespace := c.Param("namespace")
+	pod := c.Param("pod")
+
+	client, err := k.client(c)
+	if err != nil {
+		return c.JSON(clusterErrorStatus(err), err.Error())
+	}
+
+	events, err := client.GetPodEvent(c.Request().Context(), namespace, pod)
+	if err != nil {
+		return c.JSON(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, events)
+}
+
+// @Summary		Get pod logs
+// @Description	Get last 500 lines of the pod logs
+// @name		getPodLogs
+// @Accept		json
+// @Produce		plain
+// @Param		namespace	path	string	true	"namespace of the pod"
+// @Param		pod			path	string	true	"name of the pod"
+// @Param		previous	query	bool	false	"logs of the previous terminated container"
+// @Param		cluster		query	string	false	"cluster name (default cluster if empty)"
+// @Success		200			{string}	string
+// @Router		/api/v1/k8s/{namespace}/pods/{pod}/logs [get]
+// @Security    ApiKeyAuth
+func (k *K8sHandler) getPodLogs(c echo.Context) error {
+
+	namespace := c.Param("namespace")
+	pod := c.Param("pod")
+
+	previous, err := boolQueryParam(c, "previous", false)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, c.QueryParam("previous"))
+	}
+
+	client, err := k.client(c)
+	if err != nil {
+		return c.JSON(clusterErrorStatus(err), err.Error())
+	}
+
+	logs, err := client.GetPodLogs(c.Request().Context(), namespace, pod, previous)
+	if err != nil {
+		return c.JSON(errorStatus(err), err.Error())
+	}
+
+	return c.String(http.StatusOK, *logs)
+}
+
+// @Summary		Stream pod logs
+// @Description	Stream the pod logs as Server-Sent Events, one line per "data" event
+// @name		streamPodLogs
+// @Accept		json
+// @Produce		text/event-stream
+// @Param		namespace		path	string	true	"namespace of the pod"
+// @Param		pod				path	string	true	"name of the pod"
+// @Param		container		query	string	false	"container name (required for multi-container pods)"
+// @Param		follow			query	bool	false	"follow the log stream (default true)"
+// @Param		previous		query	bool	false	"logs of the previous terminated container"
+// @Param		sinceSeconds	query	int		false	"only logs newer than sinceSeconds"
+// @Param		tailLines		query	int		false	"number of lines from the end of the logs"
+// @Param		timestamps		query	bool	false	"prefix each line with RFC3339 timestamp"
+// @Param		cluster			query	string	false	"cluster name (default cluster if empty)"
+// @Success		200				{string}	string
+// @Router		/api/v1/k8s/{namespace}/pods/{pod}/logs/stream [get]
+// @Security    ApiKeyAuth
+func (k *K8sHandler) streamPodLogs(c echo.Context) error {
+
+	namespace := c.Param("namespace")
+	pod := c.Param("pod")
+
+	opts, err := podLogStreamOptions(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	client, err := k.client(c)
+	if err != nil {
+		return c.JSON(clusterErrorStatus(err), err.Error())
+	}
+
+	// client 연결이 끊기면 request context 가 취소되어 upstream stream 도 종료됨
+	ctx := c.Request().Context()
+
+	stream, err := client.StreamPodLogs(ctx, namespace, pod, opts)
+	if err != nil {
+		return c.JSON(errorStatus(err), err.Error())
+	}
+	defer stream.Close()
+
+	// 대기 중인 Read 를 깨우기 위해 ctx 취소 시 stream 을 직접 닫음
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			stream.Close()
+		case <-done:
+		}
+	}()
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set("Cache-Control", "no-cache")
+	res.Header().Set("Connection", "keep-alive")
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+	res.Flush()
+
+	scanner := bufio.NewScanner(stream)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		if _, err := fmt.Fprintf(res, "data: %s\n\n", scanner.Text()); err != nil {
+			return nil
+		}
+		res.Flush()
+	}
+
+	if err := scanner.Err(); err != nil && ctx.Err() == nil {
+		fmt.Fprintf(res, "event: error\ndata: %s\n\n", err.Error())
+		res.Flush()
+		return nil
+	}
+
+	if ctx.Err() == nil {
+		fmt.Fprint(res, "event: end\ndata: EOF\n\n")
+		res.Flush()
+	}
+
+	return nil
+}
+
+func podLogStreamOptions(c echo.Context) (*domain.PodLogStreamOptions, error) {
+	follow, err := boolQueryParam(c, "follow", true)
+	if err != nil {
+		return nil, fmt.Errorf("invalid follow: %s", c.QueryParam("follow"))
+	}
+	previous, err := boolQueryParam(c, "previous", false)
+	if err != nil {
+		return nil, fmt.Errorf("invalid previous: %s", c.QueryParam("previous"))
+	}
+	timestamps, err := boolQueryParam(c, "timestamps", false)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timestamps: %s", c.QueryParam("timestamps"))
+	}
+	sinceSeconds, err := int64QueryParam(c, "sinceSeconds")
+	if err != nil {
+		return nil, fmt.Errorf("invalid sinceSeconds: %s", c.QueryParam("sinceSeconds"))
+	}
+	tailLines, err := int64QueryParam(c, "tailLines")
+	if err != nil {
+		return nil, fmt.Errorf("invalid tailLines: %s", c.QueryParam("tailLines"))
+	}
+
+	return &domain.PodLogStreamOptions{
+		Container:    c.QueryParam("container"),
+		Follow:       follow,
+		Previous:     previous,
+		SinceSeconds: sinceSeconds,
+		TailLines:    tailLines,
+		Timestamps:   timestamps,
+	}, nil
+}
+
+// @Summary		Describe pod
+// @Description	Get description of the pod as json, or kubectl describe style text with format=text
+// @name		getPodDesc
+// @Accept		json
+// @Produce		json
+// @Produce		plain
+// @Param		namespace	path	string	true	"namespace of the pod"
+// @Param		pod			path	string	true	"name of the pod"
+// @Param		format		query	string	false	"json (default) or text"	Enums(json, text)
+// @Param		cluster		query	string	false	"cluster name (default cluster if empty)"
+// @Success		200			{object}	domain.PodDescription
+// @Router		/api/v1/k8s/{namespace}/pods/{pod}/desc [get]
+// @Security    ApiKeyAuth
+func (k *K8sHandler) getPodDesc(c echo.Context) error {
+
+	namespace := c.Param("namespace")
+	pod := c.Param("pod")
+
+	format := c.QueryParam("format")
+	if format != "" && format != "json" && format != "text" {
+		return c.JSON(http.StatusBadRequest, format)
+	}
+
+	client, err := k.client(c)
+	if err != nil {
+		return c.JSON(clusterErrorStatus(err), err.Error())
+	}
+
+	desc, err := client.GetPodDesc(c.Request().Context(), namespace, pod)
+	if err != nil {
+		return c.JSON(errorStatus(err), err.Error())
+	}
+
+	if format == "text" {
+		return c.String(http.StatusOK, desc.Text())
+	}
+
+	return c.JSON(http.StatusOK, desc)
+}
+
+// @Summary		Get workloads
+// @Description	Get workload list by namespace and kind
+// @name		getWorkloadList
+// @Accept		json
+// @Produce		json
+// @Param		namespace	path	string	true	"namespace of the workloads"
+// @Param		kind		path	string	true	"kind of the workloads"	Enums(deployments, replicasets, statefulsets, daemonsets, jobs, cronjobs)
+// @Param		cluster		query	string	false	"cluster name (default cluster if empty)"
+// @Success		200			{array}	domain.WorkloadInfo
+// @Router		/api/v1/k8s/{namespace}/workloads/{kind} [get]
+// @Security    ApiKeyAuth
+func (k *K8sHandler) getWorkloadList(c echo.Context) error {
+
+	namespace := c.Param("namespace")
+
+	kind, err := domain.ParseWorkloadKind(c.Param("kind"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	client, err := k.client(c)
+	if err != nil {
+		return c.JSON(clusterErrorStatus(err), err.Error())
+	}
+
+	workloads, err := client.GetWorkloadList(c.Request().Context(), kind, namespace)
+	if err != nil {
+		return c.JSON(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, workloads)
+}
+
+// @Summary		Get workload
+// @Description	Get workload detail with the pods selected by its label selector
+// @name		getWorkload
+// @Accept		json
+// @Produce		json
+// @Param		namespace	path	string	true	"namespace of the workload"
+// @Param		kind		path	string	true	"kind of the workload"	Enums(deployments, replicasets, statefulsets, daemonsets, jobs, cronjobs)
+// @Param		name		path	string	true	"name of the workload"
+// @Param		cluster		query	string	false	"cluster name (default cluster if empty)"
+// @Success		200			{object}	domain.WorkloadDetail
+// @Router		/api/v1/k8s/{namespace}/workloads/{kind}/{name} [get]
+// @Security    ApiKeyAuth
+func (k *K8sHandler) getWorkload(c echo.Context) error {
+
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	kind, err := domain.ParseWorkloadKind(c.Param("kind"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	client, err := k.client(c)
+	if err != nil {
+		return c.JSON(clusterErrorStatus(err), err.Error())
+	}
+
+	workload, err := client.GetWorkload(c.Request().Context(), kind, namespace, name)
+	if err != nil {
+		return c.JSON(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, workload)
+}
+
+// rollout-status 의 timeout 기본값과 최대값
+const (
+	defaultRolloutTimeout = 30 * time.Second
+	maxRolloutTimeout     = 10 * time.Minute
+)
+
+// @Summary		Scale workload
+// @Description	Change replicas of the deployment or statefulset
+// @name		scaleWorkload
+// @Accept		json
+// @Produce		json
+// @Param		namespace	path	string	true	"namespace of the workload"
+// @Param		kind		path	string	true	"kind of the workload"	Enums(deployments, statefulsets)
+// @Param		name		path	string	true	"name of the workload"
+// @Param		cluster		query	string	false	"cluster name (default cluster if empty)"
+// @Param		replicas	body	domain.ScaleWorkloadRequest	true	"replicas"
+// @Success		200			{object}	domain.WorkloadDetail
+// @Router		/api/v1/k8s/{namespace}/workloads/{kind}/{name}/scale [put]
+// @Security    ApiKeyAuth
+func (k *K8sHandler) scaleWorkload(c echo.Context) error {
+
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	kind, err := domain.ParseWorkloadKind(c.Param("kind"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	req := new(domain.ScaleWorkloadRequest)
+	if err := c.Bind(req); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	if req.Replicas < 0 {
+		return c.JSON(http.StatusBadRequest, "replicas must not be negative")
+	}
+
+	client, err := k.client(c)
+	if err != nil {
+		return c.JSON(clusterErrorStatus(err), err.Error())
+	}
+
+	err = client.ScaleWorkload(c.Request().Context(), kind, namespace, name, req.Replicas)
+	k.publishAudit(c, "k8s.workload.scale", kind, namespace, name, err)
+	if err != nil {
+		return c.JSON(errorStatus(err), err.Error())
+	}
+
+	return k.workloadResponse(c, client, kind, namespace, name)
+}
+
+// @Summary		Restart workload
+// @Description	Trigger a rollout restart of the deployment, statefulset or daemonset
+// @name		restartWorkload
+// @Accept		json
+// @Produce		json
+// @Param		namespace	path	string	true	"namespace of the workload"
+// @Param		kind		path	string	true	"kind of the workload"	Enums(deployments, statefulsets, daemonsets)
+// @Param		name		path	string	true	"name of the workload"
+// @Param		cluster		query	string	false	"cluster name (default cluster if empty)"
+// @Success		200			{object}	domain.WorkloadDetail
+// @Router		/api/v1/k8s/{namespace}/workloads/{kind}/{name}/restart [post]
+// @Security    ApiKeyAuth
+func (k *K8sHandler) restartWorkload(c echo.Context) error {
+
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	kind, err := domain.ParseWorkloadKind(c.Param("kind"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	client, err := k.client(c)
+	if err != nil {
+		return c.JSON(clusterErrorStatus(err), err.Error())
+	}
+
+	err = client.RestartWorkload(c.Request().Context(), kind, namespace, name)
+	k.publishAudit(c, "k8s.workload.restart", kind, namespace, name, err)
+	if err != nil {
+		return c.JSON(errorStatus(err), err.Error())
+	}
+
+	return k.workloadResponse(c, client, kind, namespace, name)
+}
+
+// @Summary		Rollback deployment
+// @Description	Roll back the deployment to the revision, or to the previous revision if revision is 0
+// @name		rollbackDeployment
+// @Accept		json
+// @Produce		json
+// @Param		namespace	path	string	true	"namespace of the deployment"
+// @Param		kind		path	string	true	"kind of the workload"	Enums(deployments)
+// @Param		name		path	string	true	"name of the deployment"
+// @Param		cluster		query	string	false	"cluster name (default cluster if empty)"
+// @Param		revision	body	domain.RollbackDeploymentRequest	false	"revision"
+// @Success		200			{object}	domain.WorkloadDetail
+// @Router		/api/v1/k8s/{namespace}/workloads/{kind}/{name}/rollback [post]
+// @Security    ApiKeyAuth
+func (k *K8sHandler) rollbackDeployment(c echo.Context) error {
+
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	if err := deploymentKind(c); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	req := new(domain.RollbackDeploymentRequest)
+	if err := c.Bind(req); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	if req.Revision < 0 {
+		return c.JSON(http.StatusBadRequest, "revision must not be negative")
+	}
+
+	client, err := k.client(c)
+	if err != nil {
+		return c.JSON(clusterErrorStatus(err), err.Error())
+	}
+
+	err = client.RollbackDeployment(c.Request().Context(), namespace, name, req.Revision)
+	k.publishAudit(c, "k8s.deployment.rollback", domain.Deployments, namespace, name, err)
+	if err != nil {
+		return c.JSON(errorStatus(err), err.Error())
+	}
+
+	return k.workloadResponse(c, client, domain.Deployments, namespace, name)
+}
+
+// @Summary		Pause deployment
+// @Description	Pause the rollout of the deployment
+// @name		pauseDeployment
+// @Accept		json
+// @Produce		json
+// @Param		namespace	path	string	true	"namespace of the deployment"
+// @Param		kind		path	string	true	"kind of the workload"	Enums(deployments)
+// @Param		name		path	string	true	"name of the deployment"
+// @Param		cluster		query	string	false	"cluster name (default cluster if empty)"
+// @Success		200			{object}	domain.WorkloadDetail
+// @Router		/api/v1/k8s/{namespace}/workloads/{kind}/{name}/pause [post]
+// @Security    ApiKeyAuth
+func (k *K8sHandler) pauseDeployment(c echo.Context) error {
+	return k.setDeploymentPaused(c, true)
+}
+
+// @Summary		Resume deployment
+// @Description	Resume the paused rollout of the deployment
+// @name		resumeDeployment
+// @Accept		json
+// @Produce		json
+// @Param		namespace	path	string	true	"namespace of the deployment"
+// @Param		kind		path	string	true	"kind of the workload"	Enums(deployments)
+// @Param		name		path	string	true	"name of the deployment"
+// @Param		cluster		query	string	false	"cluster name (default cluster if empty)"
+// @Success		200			{object}	domain.WorkloadDetail
+// @Router		/api/v1/k8s/{namespace}/workloads/{kind}/{name}/resume [post]
+// @Security    ApiKeyAuth
+func (k *K8sHandler) resumeDeployment(c echo.Context) error {
+	return k.setDeploymentPaused(c, false)
+}
+
+func (k *K8sHandler) setDeploymentPaused(c echo.Context, paused bool) error {
+
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	if err := deploymentKind(c); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	client, err := k.client(c)
+	if err != nil {
+		return c.JSON(clusterErrorStatus(err), err.Error())
+	}
+
+	action := "k8s.deployment.resume"
+	if paused {
+		action = "k8s.deployment.pause"
+	}
+
+	err = client.SetDeploymentPaused(c.Request().Context(), namespace, name, paused)
+	k.publishAudit(c, action, domain.Deployments, namespace, name, err)
+	if err != nil {
+		return c.JSON(errorStatus(err), err.Error())
+	}
+
+	return k.workloadResponse(c, client, domain.Deployments, namespace, name)
+}
+
+// @Summary		Get rollout status
+// @Description	Wait until the rollout completes, fails or the timeout expires
+// @name		getRolloutStatus
+// @Accept		json
+// @Produce		json
+// @Param		namespace	path	string	true	"namespace of the workload"
+// @Param		kind		path	string	true	"kind of the workload"	Enums(deployments, statefulsets, daemonsets)
+// @Param		name		path	string	true	"name of the workload"
+// @Param		timeout		query	string	false	"how long to wait, e.g. 30s (default 30s, max 10m, 0 to check once)"
+// @Param		cluster		query	string	false	"cluster name (default cluster if empty)"
+// @Success		200			{object}	domain.RolloutStatus
+// @Router		/api/v1/k8s/{namespace}/workloads/{kind}/{name}/rollout-status [get]
+// @Security    ApiKeyAuth
+func (k *K8sHandler) getRolloutStatus(c echo.Context) error {
+
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	kind, err := domain.ParseWorkloadKind(c.Param("kind"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	timeout := defaultRolloutTimeout
+	if param := c.QueryParam("timeout"); param != "" {
+		timeout, err = time.ParseDuration(param)
+		if err != nil || timeout < 0 || timeout > maxRolloutTimeout {
+			return c.JSON(http.StatusBadRequest, fmt.Sprintf("invalid timeout: %s", param))
+		}
+	}
+
+	client, err := k.client(c)
+	if err != nil {
+		return c.JSON(clusterErrorStatus(err), err.Error())
+	}
+
+	status, err := client.GetRolloutStatus(c.Request().Context(), kind, namespace, name, timeout)
+	if err != nil {
+		return c.JSON(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, status)
+}
+
+// rollback, pause, resume 은 deployment 만 지원
+func deploymentKind(c echo.Context) error {
+	kind, err := domain.ParseWorkloadKind(c.Param("kind"))
+	if err != nil {
+		return err
+	}
+	if kind != domain.Deployments {
+		return fmt.Errorf("%w: only deployments are supported", domain.ErrUnsupportedWorkloadKind)
+	}
+	return nil
+}
+
+// audit target 은 cluster/namespace/kind/name
+func (k *K8sHandler) publishAudit(c echo.Context, action string, kind domain.WorkloadKind, namespace, name string, err error) {
+	cluster := c.QueryParam("cluster")
+	if cluster == "" {
+		cluster = k.clusters.DefaultCluster()
+	}
+	target := fmt.Sprintf("%s/%s/%s/%s", cluster, namespace, kind, name)
+
+	k.audit.Publish(security.Actor(c), action, target, err)
+}
+
+// 변경 후 workload 상세 반환
+func (k *K8sHandler) workloadResponse(c echo.Context, client domain.K8sClientHandler, kind domain.WorkloadKind, namespace, name string) error {
+	workload, err := client.GetWorkload(c.Request().Context(), kind, namespace, name)
+	if err != nil {
+		return c.JSON(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, workload)
+}
@@ -0,0 +1,662 @@
+package http
+
+import (
+	"backend/config"
+	"backend/internal/pkg/domain"
+	"backend/internal/pkg/model"
+	"backend/internal/pkg/security"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// fake clientset 기반 handler 생성
+func newTestK8sHandler() *K8sHandler {
+	started := true
+	startTime := metav1.NewTime(time.Now())
+
+	clientSet := fake.NewSimpleClientset(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "nginx", Namespace: "default"},
+			Spec: appsv1.DeploymentSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "nginx"}},
+				Template: v1.PodTemplateSpec{Spec: v1.PodSpec{Containers: []v1.Container{{Name: "nginx", Image: "nginx"}}}},
+			},
+			Status: appsv1.DeploymentStatus{ReadyReplicas: 1},
+		},
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "nginx", Namespace: "default", Labels: map[string]string{"app": "nginx"}},
+			Spec:       v1.PodSpec{NodeName: "node-1", Containers: []v1.Container{{Name: "nginx", Image: "nginx"}}},
+			Status: v1.PodStatus{
+				Phase:     v1.PodRunning,
+				PodIP:     "10.0.0.1",
+				StartTime: &startTime,
+				ContainerStatuses: []v1.ContainerStatus{
+					{Name: "nginx", Started: &started, Ready: true, State: v1.ContainerState{Running: &v1.ContainerStateRunning{StartedAt: startTime}}},
+				},
+			},
+		},
+		&v1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "nginx.1", Namespace: "default"},
+			InvolvedObject: v1.ObjectReference{Kind: "Pod", Name: "nginx", Namespace: "default"},
+			Type:           "Normal",
+			Reason:         "Pulled",
+			Message:        "Container image pulled",
+		},
+	)
+
+	return &K8sHandler{
+		clusters: domain.NewK8sClusterRegistryWithClients("dev", map[string]domain.K8sClientHandler{
+			"dev":  domain.NewK8sClientSetHandlerWithClientSet(clientSet),
+			"prod": domain.NewK8sClientSetHandlerWithClientSet(fake.NewSimpleClientset()),
+		}),
+		audit: domain.NewAuditPublisher(&domain.LogMessageSender{}, config.Config{}),
+	}
+}
+
+// 전송된 audit event 기록
+type recordingSender struct {
+	mu     sync.Mutex
+	events []*domain.AuditEvent
+}
+
+func (r *recordingSender) SendMessage(message *domain.Message) error {
+	event := &domain.AuditEvent{}
+	if err := json.Unmarshal(message.Payload, event); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+	return nil
+}
+
+// audit event 는 background 로 전송되므로 n 개가 기록될 때까지 최대 1초 대기
+func (r *recordingSender) wait(n int) []*domain.AuditEvent {
+	for deadline := time.Now().Add(time.Second); ; time.Sleep(5 * time.Millisecond) {
+		r.mu.Lock()
+		events := append([]*domain.AuditEvent(nil), r.events...)
+		r.mu.Unlock()
+		if len(events) >= n || time.Now().After(deadline) {
+			return events
+		}
+	}
+}
+
+func (r *recordingSender) Close() error {
+	return nil
+}
+
+func TestGetClusters(t *testing.T) {
+
+	e := echo.New()
+	h := newTestK8sHandler()
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+
+	c := e.NewContext(req, rec)
+
+	if assert.NoError(t, h.getClusters(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+
+	clusters := []*domain.ClusterStatus{}
+	err := json.NewDecoder(rec.Body).Decode(&clusters)
+	assert.NoError(t, err)
+	if assert.Equal(t, 2, len(clusters)) {
+		assert.Equal(t, "dev", clusters[0].Name)
+		assert.True(t, clusters[0].Default)
+		assert.True(t, clusters[0].Reachable)
+		assert.Equal(t, "prod", clusters[1].Name)
+		assert.False(t, clusters[1].Default)
+		assert.True(t, clusters[1].Reachable)
+	}
+}
+
+func TestGetPodList(t *testing.T) {
+
+	e := echo.New()
+	h := newTestK8sHandler()
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+
+	c := e.NewContext(req, rec)
+	c.SetPath("/:namespace/pods")
+	c.SetParamNames("namespace")
+	c.SetParamValues("default")
+
+	if assert.NoError(t, h.getPodList(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+
+	pods := []*domain.PodInfo{}
+	err := json.NewDecoder(rec.Body).Decode(&pods)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(pods))
+	assert.Equal(t, "nginx", pods[0].Name)
+	assert.Equal(t, "node-1", pods[0].NodeName)
+	assert.Equal(t, 1, pods[0].CurrentCount)
+
+	// cluster 지정 조회
+	req = httptest.NewRequest(http.MethodGet, "/?cluster=prod", nil)
+	rec = httptest.NewRecorder()
+
+	c = e.NewContext(req, rec)
+	c.SetPath("/:namespace/pods")
+	c.SetParamNames("namespace")
+	c.SetParamValues("default")
+
+	if assert.NoError(t, h.getPodList(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+
+	pods = []*domain.PodInfo{}
+	err = json.NewDecoder(rec.Body).Decode(&pods)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(pods))
+
+	// 없는 cluster
+	req = httptest.NewRequest(http.MethodGet, "/?cluster=unknown", nil)
+	rec = httptest.NewRecorder()
+
+	c = e.NewContext(req, rec)
+	c.SetPath("/:namespace/pods")
+	c.SetParamNames("namespace")
+	c.SetParamValues("default")
+
+	if assert.NoError(t, h.getPodList(c)) {
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	}
+}
+
+func TestGetPodEvents(t *testing.T) {
+
+	e := echo.New()
+	h := newTestK8sHandler()
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+
+	c := e.NewContext(req, rec)
+	c.SetPath("/:namespace/pods/:pod/events")
+	c.SetParamNames("namespace", "pod")
+	c.SetParamValues("default", "nginx")
+
+	if assert.NoError(t, h.getPodEvents(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+
+	events := []*domain.PodEvent{}
+	err := json.NewDecoder(rec.Body).Decode(&events)
+	assert.NoError(t, err)
+	if assert.Equal(t, 1, len(events)) {
+		assert.Equal(t, "Pulled", events[0].Reason)
+	}
+}
+
+func TestGetPodLogs(t *testing.T) {
+
+	e := echo.New()
+	h := newTestK8sHandler()
+
+	// fake clientset 은 항상 "fake logs" 를 반환
+	req := httptest.NewRequest(http.MethodGet, "/?previous=true", nil)
+	rec := httptest.NewRecorder()
+
+	c := e.NewContext(req, rec)
+	c.SetPath("/:namespace/pods/:pod/logs")
+	c.SetParamNames("namespace", "pod")
+	c.SetParamValues("default", "nginx")
+
+	if assert.NoError(t, h.getPodLogs(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "fake logs", rec.Body.String())
+	}
+
+	// previous 값이 bool 이 아닌 경우
+	req = httptest.NewRequest(http.MethodGet, "/?previous=abc", nil)
+	rec = httptest.NewRecorder()
+
+	c = e.NewContext(req, rec)
+	c.SetPath("/:namespace/pods/:pod/logs")
+	c.SetParamNames("namespace", "pod")
+	c.SetParamValues("default", "nginx")
+
+	if assert.NoError(t, h.getPodLogs(c)) {
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	}
+}
+
+func TestStreamPodLogs(t *testing.T) {
+
+	e := echo.New()
+	h := newTestK8sHandler()
+
+	req := httptest.NewRequest(http.MethodGet, "/?container=nginx&tailLines=10&sinceSeconds=60&timestamps=true&follow=false", nil)
+	rec := httptest.NewRecorder()
+
+	c := e.NewContext(req, rec)
+	c.SetPath("/:namespace/pods/:pod/logs/stream")
+	c.SetParamNames("namespace", "pod")
+	c.SetParamValues("default", "nginx")
+
+	if assert.NoError(t, h.streamPodLogs(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "text/event-stream", rec.Header().Get(echo.HeaderContentType))
+		assert.Equal(t, "data: fake logs\n\nevent: end\ndata: EOF\n\n", rec.Body.String())
+	}
+
+	// 잘못된 option
+	for _, query := range []string{"tailLines=-1", "sinceSeconds=abc", "timestamps=abc", "follow=abc"} {
+		req = httptest.NewRequest(http.MethodGet, "/?"+query, nil)
+		rec = httptest.NewRecorder()
+
+		c = e.NewContext(req, rec)
+		c.SetPath("/:namespace/pods/:pod/logs/stream")
+		c.SetParamNames("namespace", "pod")
+		c.SetParamValues("default", "nginx")
+
+		if assert.NoError(t, h.streamPodLogs(c)) {
+			assert.Equal(t, http.StatusBadRequest, rec.Code, query)
+		}
+	}
+}
+
+// follow 중인 stream 을 흉내내기 위한 client
+type streamingK8sClient struct {
+	domain.K8sClientHandler
+	reader *io.PipeReader
+	opts   *domain.PodLogStreamOptions
+}
+
+func (s *streamingK8sClient) StreamPodLogs(ctx context.Context, namespace, podName string, opts *domain.PodLogStreamOptions) (io.ReadCloser, error) {
+	s.opts = opts
+	return s.reader, nil
+}
+
+func TestStreamPodLogsClientDisconnect(t *testing.T) {
+
+	e := echo.New()
+
+	reader, writer := io.Pipe()
+	client := &streamingK8sClient{reader: reader}
+	h := &K8sHandler{
+		clusters: domain.NewK8sClusterRegistryWithClients("dev", map[string]domain.K8sClientHandler{"dev": client}),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+	rec := httptest.NewRecorder()
+
+	c := e.NewContext(req, rec)
+	c.SetPath("/:namespace/pods/:pod/logs/stream")
+	c.SetParamNames("namespace", "pod")
+	c.SetParamValues("default", "nginx")
+
+	result := make(chan error)
+	go func() {
+		result <- h.streamPodLogs(c)
+	}()
+
+	_, err := writer.Write([]byte("line 1\n"))
+	assert.NoError(t, err)
+
+	// client 연결 종료
+	cancel()
+
+	select {
+	case err := <-result:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("streamPodLogs did not return after client disconnect")
+	}
+
+	// upstream stream 이 닫혀 더 이상 쓸 수 없어야 함
+	_, err = writer.Write([]byte("line 2\n"))
+	assert.ErrorIs(t, err, io.ErrClosedPipe)
+
+	assert.True(t, client.opts.Follow)
+	assert.Contains(t, rec.Body.String(), "data: line 1\n\n")
+	assert.NotContains(t, rec.Body.String(), "line 2")
+}
+
+// request context 를 받아 제한 시간까지 대기하는 client
+type slowK8sClient struct {
+	domain.K8sClientHandler
+}
+
+func (s *slowK8sClient) GetPodList(ctx context.Context, namespace string) ([]*domain.PodInfo, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestGetPodListTimeout(t *testing.T) {
+
+	e := echo.New()
+	h := &K8sHandler{
+		clusters: domain.NewK8sClusterRegistryWithClients("dev", map[string]domain.K8sClientHandler{"dev": &slowK8sClient{}}),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	req := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+	rec := httptest.NewRecorder()
+
+	c := e.NewContext(req, rec)
+	c.SetPath("/:namespace/pods")
+	c.SetParamNames("namespace")
+	c.SetParamValues("default")
+
+	if assert.NoError(t, h.getPodList(c)) {
+		assert.Equal(t, http.StatusGatewayTimeout, rec.Code)
+	}
+}
+
+func TestGetPodDesc(t *testing.T) {
+
+	e := echo.New()
+	h := newTestK8sHandler()
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+
+	c := e.NewContext(req, rec)
+	c.SetPath("/:namespace/pods/:pod/desc")
+	c.SetParamNames("namespace", "pod")
+	c.SetParamValues("default", "nginx")
+
+	if assert.NoError(t, h.getPodDesc(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+
+	desc := &domain.PodDescription{}
+	err := json.NewDecoder(rec.Body).Decode(desc)
+	assert.NoError(t, err)
+	assert.Equal(t, "nginx", desc.Name)
+	assert.Equal(t, "Running", desc.Status)
+	if assert.Equal(t, 1, len(desc.Containers)) {
+		assert.Equal(t, "Running", desc.Containers[0].State.State)
+		assert.True(t, desc.Containers[0].Ready)
+	}
+
+	// kubectl describe 형식
+	req = httptest.NewRequest(http.MethodGet, "/?format=text", nil)
+	rec = httptest.NewRecorder()
+
+	c = e.NewContext(req, rec)
+	c.SetPath("/:namespace/pods/:pod/desc")
+	c.SetParamNames("namespace", "pod")
+	c.SetParamValues("default", "nginx")
+
+	if assert.NoError(t, h.getPodDesc(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "Name:             nginx\n")
+		assert.Contains(t, rec.Body.String(), "Status:           Running\n")
+	}
+
+	// 지원하지 않는 format
+	req = httptest.NewRequest(http.MethodGet, "/?format=yaml", nil)
+	rec = httptest.NewRecorder()
+
+	c = e.NewContext(req, rec)
+	c.SetPath("/:namespace/pods/:pod/desc")
+	c.SetParamNames("namespace", "pod")
+	c.SetParamValues("default", "nginx")
+
+	if assert.NoError(t, h.getPodDesc(c)) {
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	}
+
+	// 없는 pod 조회
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	rec = httptest.NewRecorder()
+
+	c = e.NewContext(req, rec)
+	c.SetPath("/:namespace/pods/:pod/desc")
+	c.SetParamNames("namespace", "pod")
+	c.SetParamValues("default", "unknown")
+
+	if assert.NoError(t, h.getPodDesc(c)) {
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	}
+}
+
+func TestK8sRouteRequiresToken(t *testing.T) {
+
+	e := echo.New()
+	cfg := config.Config{
+		JWTSigningKey: "jwtkey",
+		SqliteDBPath:  filepath.Join(t.TempDir(), "gorm.db"),
+		Clusters: []config.Cluster{
+			{Name: "broken", KubeConfig: "not a kubeconfig"},
+		},
+	}
+
+	db, err := model.NewDBHandler(cfg)
+	assert.NoError(t, err)
+	security.WebSecurityConfig(e, cfg, db)
+	NewK8sHandler(e, cfg, domain.NewAuditPublisher(&domain.LogMessageSender{}, cfg))
+
+	// token 없이 호출 시 401
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/k8s/default/pods", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	// token 이 있으나 kubeconfig 가 올바르지 않은 경우 503
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/k8s/default/pods", nil)
+	token, _ := security.JsonWebTokenIssuer(cfg, 1, "a", security.RoleViewer)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	// viewer 는 workload 변경 불가
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/k8s/default/workloads/deployments/nginx/restart", nil)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestGetWorkloads(t *testing.T) {
+
+	e := echo.New()
+	h := newTestK8sHandler()
+
+	// 1. 목록 조회
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+
+	c := e.NewContext(req, rec)
+	c.SetPath("/:namespace/workloads/:kind")
+	c.SetParamNames("namespace", "kind")
+	c.SetParamValues("default", "deployments")
+
+	if assert.NoError(t, h.getWorkloadList(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+
+	workloads := []*domain.WorkloadInfo{}
+	err := json.NewDecoder(rec.Body).Decode(&workloads)
+	assert.NoError(t, err)
+	if assert.Equal(t, 1, len(workloads)) {
+		assert.Equal(t, "nginx", workloads[0].Name)
+		assert.Equal(t, int32(1), workloads[0].Ready)
+	}
+
+	// 2. 상세 조회, selector 로 pod 연결
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	rec = httptest.NewRecorder()
+
+	c = e.NewContext(req, rec)
+	c.SetPath("/:namespace/workloads/:kind/:name")
+	c.SetParamNames("namespace", "kind", "name")
+	c.SetParamValues("default", "deployments", "nginx")
+
+	if assert.NoError(t, h.getWorkload(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+
+	workload := &domain.WorkloadDetail{}
+	err = json.NewDecoder(rec.Body).Decode(workload)
+	assert.NoError(t, err)
+	assert.Equal(t, "app=nginx", workload.Selector)
+	if assert.Equal(t, 1, len(workload.Pods)) {
+		assert.Equal(t, "nginx", workload.Pods[0].Name)
+	}
+
+	// 3. 없는 workload
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	rec = httptest.NewRecorder()
+
+	c = e.NewContext(req, rec)
+	c.SetPath("/:namespace/workloads/:kind/:name")
+	c.SetParamNames("namespace", "kind", "name")
+	c.SetParamValues("default", "statefulsets", "nginx")
+
+	if assert.NoError(t, h.getWorkload(c)) {
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	}
+
+	// 4. 지원하지 않는 kind
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	rec = httptest.NewRecorder()
+
+	c = e.NewContext(req, rec)
+	c.SetPath("/:namespace/workloads/:kind")
+	c.SetParamNames("namespace", "kind")
+	c.SetParamValues("default", "services")
+
+	if assert.NoError(t, h.getWorkloadList(c)) {
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	}
+}
+
+func TestRolloutOperations(t *testing.T) {
+
+	e := echo.New()
+	h := newTestK8sHandler()
+
+	sender := &recordingSender{}
+	h.audit = domain.NewAuditPublisher(sender, config.Config{})
+
+	newContext := func(method, path, body, kind, name string) (echo.Context, *httptest.ResponseRecorder) {
+		req := httptest.NewRequest(method, "/", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+
+		c := e.NewContext(req, rec)
+		c.SetPath("/:namespace/workloads/:kind/:name/" + path)
+		c.SetParamNames("namespace", "kind", "name")
+		c.SetParamValues("default", kind, name)
+
+		return c, rec
+	}
+
+	// 1. scale
+	c, rec := newContext(http.MethodPut, "scale", `{"replicas":3}`, "deployments", "nginx")
+	if assert.NoError(t, h.scaleWorkload(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+
+	workload := &domain.WorkloadDetail{}
+	err := json.NewDecoder(rec.Body).Decode(workload)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), workload.Desired)
+
+	if events := sender.wait(1); assert.Equal(t, 1, len(events)) {
+		assert.Equal(t, "k8s.workload.scale", events[0].Action)
+		assert.Equal(t, "dev/default/deployments/nginx", events[0].Target)
+		assert.Equal(t, domain.AuditOutcomeSuccess, events[0].Outcome)
+		assert.Equal(t, "anonymous", events[0].Actor)
+	}
+
+	c, rec = newContext(http.MethodPut, "scale", `{"replicas":-1}`, "deployments", "nginx")
+	if assert.NoError(t, h.scaleWorkload(c)) {
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	}
+
+	c, rec = newContext(http.MethodPut, "scale", `{"replicas":1}`, "daemonsets", "nginx")
+	if assert.NoError(t, h.scaleWorkload(c)) {
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	}
+
+	c, rec = newContext(http.MethodPut, "scale", `{"replicas":1}`, "deployments", "unknown")
+	if assert.NoError(t, h.scaleWorkload(c)) {
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	}
+
+	// 요청 검증 실패는 기록하지 않고 k8s 호출 실패는 failure 로 기록
+	if events := sender.wait(3); assert.Equal(t, 3, len(events)) {
+		assert.Equal(t, domain.AuditOutcomeFailure, events[2].Outcome)
+		assert.NotEmpty(t, events[2].Error)
+	}
+
+	// 2. pause 상태에서 restart 는 409
+	c, rec = newContext(http.MethodPost, "pause", "", "deployments", "nginx")
+	if assert.NoError(t, h.pauseDeployment(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+
+	c, rec = newContext(http.MethodPost, "restart", "", "deployments", "nginx")
+	if assert.NoError(t, h.restartWorkload(c)) {
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	}
+
+	c, rec = newContext(http.MethodPost, "resume", "", "deployments", "nginx")
+	if assert.NoError(t, h.resumeDeployment(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+
+	c, rec = newContext(http.MethodPost, "restart", "", "deployments", "nginx")
+	if assert.NoError(t, h.restartWorkload(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+
+	// 3. rollback 은 deployment 만, replicaset 이 없으면 404
+	c, rec = newContext(http.MethodPost, "rollback", `{"revision":0}`, "statefulsets", "nginx")
+	if assert.NoError(t, h.rollbackDeployment(c)) {
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	}
+
+	c, rec = newContext(http.MethodPost, "rollback", `{"revision":0}`, "deployments", "nginx")
+	if assert.NoError(t, h.rollbackDeployment(c)) {
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	}
+
+	// 4. rollout status, timeout 0 이면 한 번만 확인
+	c, rec = newContext(http.MethodGet, "rollout-status", "", "deployments", "nginx")
+	c.QueryParams().Set("timeout", "0s")
+	if assert.NoError(t, h.getRolloutStatus(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+
+	status := &domain.RolloutStatus{}
+	err = json.NewDecoder(rec.Body).Decode(status)
+	assert.NoError(t, err)
+	assert.False(t, status.Complete)
+	assert.Contains(t, status.Message, "timed out")
+
+	c, rec = newContext(http.MethodGet, "rollout-status", "", "deployments", "nginx")
+	c.QueryParams().Set("timeout", "1h")
+	if assert.NoError(t, h.getRolloutStatus(c)) {
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	}
+}
@@ -0,0 +1,149 @@
+package model
+
+import (
+	"backend/config"
+	"backend/internal/pkg/ctxutil"
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// cfg.DB 미설정 시 사용
+const DefaultDialect = "sqlite"
+
+// db 종류별 gorm dialector 와 driver error 확인
+//
+// migration 은 migrations/<gorm Dialector.Name()> 의 sql 사용
+type Dialect struct {
+	Open func(cfg config.Config) gorm.Dialector
+	// unique, primary key 제약 위반 error 면 driver 가 알려준 column 또는 index 이름 반환
+	UniqueViolation func(err error) (constraint string, ok bool)
+}
+
+var dialects = map[string]*Dialect{}
+
+// 이름(cfg.DB 값)으로 dialect 등록, 각 dialect 파일의 init 에서 호출
+func RegisterDialect(name string, dialect *Dialect) {
+	if _, ok := dialects[name]; ok {
+		panic(fmt.Sprintf("dialect %q is already registered", name))
+	}
+	dialects[name] = dialect
+}
+
+func Dialects() []string {
+	names := make([]string, 0, len(dialects))
+	for name := range dialects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func lookupDialect(name string) (*Dialect, error) {
+	if name == "" {
+		name = DefaultDialect
+	}
+	dialect, ok := dialects[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown db %q, available: %s", name, strings.Join(Dialects(), ", "))
+	}
+	return dialect, nil
+}
+
+// 최대 재시도 간격
+const maxConnectBackoff = 30 * time.Second
+
+// 테스트에서 대기 시간 변경
+var sleep = time.Sleep
+
+// 실패 시 backoff 부터 2배씩 늘려가며 retries 번 재시도
+func retry(retries int, backoff time.Duration, fn func() error) error {
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= retries {
+			return err
+		}
+
+		log.Printf("db connection failed, retry %d/%d in %s: %v", attempt+1, retries, backoff, err)
+		sleep(backoff)
+		if backoff *= 2; backoff > maxConnectBackoff {
+			backoff = maxConnectBackoff
+		}
+	}
+}
+
+func ping(ctx context.Context, db *sql.DB, timeout time.Duration) error {
+	ctx, cancel := ctxutil.WithTimeout(ctx, timeout)
+	defer cancel()
+	return db.PingContext(ctx)
+}
+
+// pool 설정 후 ping 이 성공할 때까지 재시도
+func open(cfg config.Config) (*gorm.DB, *Dialect, error) {
+	dialect, err := lookupDialect(cfg.DB)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	db, err := gorm.Open(dialect.Open(cfg), &gorm.Config{DisableAutomaticPing: true})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pool := cfg.DBPool
+	sqlDB.SetMaxOpenConns(pool.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(pool.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(pool.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(pool.ConnMaxIdleTime)
+
+	err = retry(pool.ConnectRetries, pool.ConnectBackoff, func() error {
+		return ping(context.Background(), sqlDB, pool.PingTimeout)
+	})
+	if err != nil {
+		sqlDB.Close()
+		return nil, nil, fmt.Errorf("connect to %s db: %w", db.Dialector.Name(), err)
+	}
+
+	return db, dialect, nil
+}
+
+// migration 을 적용하지 않고 db 연결, migrate 명령에서 사용
+func OpenDB(cfg config.Config) (*gorm.DB, error) {
+	db, _, err := open(cfg)
+	return db, err
+}
+
+// cfg.DB 의 dialect 로 연결 후 migration 적용, 알 수 없는 db 는 error
+//
+// 종료 시 Close 로 connection pool 정리
+func NewDBHandler(cfg config.Config) (DBHandler, error) {
+	db, dialect, err := open(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := migrate(db); err != nil {
+		if sqlDB, _ := db.DB(); sqlDB != nil {
+			sqlDB.Close()
+		}
+		return nil, err
+	}
+
+	return &gormRepository{
+		db:          db,
+		dialect:     dialect,
+		timeout:     cfg.Timeouts.DB,
+		pingTimeout: cfg.DBPool.PingTimeout,
+	}, nil
+}
@@ -0,0 +1,141 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DBHandler 가 반환하는 error, errors.Is 로 구분
+var (
+	ErrNotFound   = errors.New("not found")
+	ErrConflict   = errors.New("conflict")
+	ErrValidation = errors.New("validation failed")
+)
+
+func validationError(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrValidation, fmt.Sprintf(format, args...))
+}
+
+// gorm/driver error 를 sentinel error 로 변환, unique 는 driver 별 unique 제약 위반 확인
+//
+// 응답에 그대로 쓰이므로 conflict 는 driver error 대신 field 이름만 포함, driver error 는 log 로 출력
+func dbError(err error, unique func(error) (string, bool)) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrNotFound
+	}
+	if constraint, ok := unique(err); ok {
+		log.Printf("db unique violation: %v", err)
+		if field := conflictField(constraint); field != "" {
+			return fmt.Errorf("%w: %s already exists", ErrConflict, field)
+		}
+		return fmt.Errorf("%w: record already exists", ErrConflict)
+	}
+	return err
+}
+
+// index 이름 idx_<table>_<column> 에서 column 을 찾을 table
+var conflictTables = []string{"users", "refresh_tokens", "revoked_tokens", "api_keys"}
+
+// driver 가 알려준 column(users.email) 또는 index(idx_users_email) 이름을 field 이름으로 변환
+//
+// primary key 등 알 수 없는 이름은 빈 문자열
+func conflictField(constraint string) string {
+	if i := strings.LastIndex(constraint, "."); i >= 0 {
+		constraint = constraint[i+1:]
+	}
+	if !strings.HasPrefix(constraint, "idx_") {
+		if constraint == "PRIMARY" || strings.HasSuffix(constraint, "_pkey") {
+			return ""
+		}
+		return constraint
+	}
+	for _, table := range conflictTables {
+		if field := strings.TrimPrefix(constraint, "idx_"+table+"_"); field != constraint {
+			return field
+		}
+	}
+	return ""
+}
+
+var usernamePattern = regexp.MustCompile(`^[A-Za-z0-9._-]{3,32}$`)
+
+// email 은 소문자로 저장하여 대소문자 구분 없이 비교
+func (u *User) normalize() {
+	u.Username = strings.TrimSpace(u.Username)
+	u.Email = strings.ToLower(strings.TrimSpace(u.Email))
+}
+
+// 생성 시 이름 필수, 수정 시에는 입력된 값만 확인
+func (u *User) validate(create bool) error {
+	if create && u.Name == "" {
+		return validationError("name is required")
+	}
+	if u.Username != "" && !usernamePattern.MatchString(u.Username) {
+		return validationError("username must be 3-32 letters, digits, '.', '_' or '-'")
+	}
+	if u.Email != "" && !validEmail(u.Email) {
+		return validationError("invalid email %q", u.Email)
+	}
+	if u.Status != "" && !u.Status.Valid() {
+		return validationError("status must be active, disabled or locked")
+	}
+	if u.Age < 0 {
+		return validationError("age must not be negative")
+	}
+	if u.Birthday.After(time.Now()) {
+		return validationError("birthday must not be in the future")
+	}
+	return nil
+}
+
+func validEmail(email string) bool {
+	addr, err := mail.ParseAddress(email)
+	return err == nil && addr.Name == "" && addr.Address == email
+}
+
+// birthday 기준 만 나이
+func ageAt(birthday, now time.Time) int {
+	now = now.In(birthday.Location())
+	age := now.Year() - birthday.Year()
+	if now.Month() < birthday.Month() || (now.Month() == birthday.Month() && now.Day() < birthday.Day()) {
+		age--
+	}
+	return age
+}
+
+// age 미입력 시 birthday 로 계산, 둘 다 있으면 일치해야 함
+//
+// 수정 시에는 origin 의 값과 합쳐서 확인
+func (u *User) checkAge(origin *User) error {
+	age, birthday := u.Age, u.Birthday
+	if origin != nil {
+		if age == 0 {
+			age = origin.Age
+		}
+		if birthday.IsZero() {
+			birthday = origin.Birthday
+		}
+	}
+	if birthday.IsZero() {
+		return nil
+	}
+
+	expected := ageAt(birthday, time.Now())
+	if u.Age == 0 && !u.Birthday.IsZero() {
+		u.Age, age = expected, expected
+	}
+	if age != expected {
+		return validationError("age %d does not match birthday (%d)", age, expected)
+	}
+	return nil
+}
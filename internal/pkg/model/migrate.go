@@ -0,0 +1,445 @@
+package model
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// dialect(gorm Dialector.Name) 별 "<version>_<name>.up.sql", "<version>_<name>.down.sql"
+//
+//go:embed migrations
+var migrationFiles embed.FS
+
+var ErrSchemaTooNew = errors.New("database schema is newer than this binary")
+
+const migrationTable = "schema_migrations"
+
+// postgres advisory lock key, 임의의 고정값
+const migrationLockID = 7310231
+
+type migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// 적용된 version 기록
+type SchemaMigration struct {
+	Version   int       `json:"version" gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `json:"name"`
+	AppliedAt time.Time `json:"appliedAt"`
+}
+
+func (SchemaMigration) TableName() string {
+	return migrationTable
+}
+
+type MigrationStatus struct {
+	Version   int        `json:"version"`
+	Name      string     `json:"name"`
+	AppliedAt *time.Time `json:"appliedAt"`
+}
+
+// version 순서로 정렬된 migration 목록
+func loadMigrations(dialect string) ([]*migration, error) {
+	dir := path.Join("migrations", dialect)
+	entries, err := fs.ReadDir(migrationFiles, dir)
+	if err != nil {
+		return nil, fmt.Errorf("no migrations for dialect %q: %w", dialect, err)
+	}
+
+	byVersion := make(map[int]*migration)
+	for _, entry := range entries {
+		file := entry.Name()
+		var direction string
+		switch {
+		case strings.HasSuffix(file, ".up.sql"):
+			direction = "up"
+		case strings.HasSuffix(file, ".down.sql"):
+			direction = "down"
+		default:
+			continue
+		}
+
+		base := strings.TrimSuffix(file, "."+direction+".sql")
+		version, name, _ := strings.Cut(base, "_")
+		v, err := strconv.Atoi(version)
+		if err != nil || v <= 0 {
+			return nil, fmt.Errorf("invalid migration file name %q", file)
+		}
+
+		b, err := fs.ReadFile(migrationFiles, path.Join(dir, file))
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[v]
+		if !ok {
+			m = &migration{Version: v, Name: name}
+			byVersion[v] = m
+		}
+		if m.Name != name {
+			return nil, fmt.Errorf("migration %d has different names %q and %q", v, m.Name, name)
+		}
+		if direction == "up" {
+			m.Up = string(b)
+		} else {
+			m.Down = string(b)
+		}
+	}
+
+	migrations := make([]*migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up file", m.Version, m.Name)
+		}
+		migrations = append(migrations, m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+// ";" 로 구분된 sql 을 문장 단위로 분리, "--" 주석 줄은 제외
+func splitStatements(sql string) []string {
+	var lines []string
+	for _, line := range strings.Split(sql, "\n") {
+		if !strings.HasPrefix(strings.TrimSpace(line), "--") {
+			lines = append(lines, line)
+		}
+	}
+
+	var statements []string
+	for _, statement := range strings.Split(strings.Join(lines, "\n"), ";") {
+		if statement = strings.TrimSpace(statement); statement != "" {
+			statements = append(statements, statement)
+		}
+	}
+	return statements
+}
+
+type Migrator struct {
+	db         *gorm.DB
+	migrations []*migration
+}
+
+func NewMigrator(db *gorm.DB) (*Migrator, error) {
+	migrations, err := loadMigrations(db.Dialector.Name())
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// binary 가 알고 있는 마지막 version
+func (m *Migrator) Latest() int {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+func (m *Migrator) applied() ([]*SchemaMigration, error) {
+	var applied []*SchemaMigration
+	if !m.db.Migrator().HasTable(migrationTable) {
+		return applied, nil
+	}
+	err := m.db.Order("version").Find(&applied).Error
+	return applied, err
+}
+
+// 마지막으로 적용된 version, 없으면 0
+func (m *Migrator) Version() (int, error) {
+	applied, err := m.applied()
+	if err != nil || len(applied) == 0 {
+		return 0, err
+	}
+	return applied[len(applied)-1].Version, nil
+}
+
+// db 의 schema 가 binary 보다 새로우면 ErrSchemaTooNew
+func (m *Migrator) Check() error {
+	version, err := m.Version()
+	if err != nil {
+		return err
+	}
+	if version > m.Latest() {
+		return fmt.Errorf("%w: database version %d, latest known version %d", ErrSchemaTooNew, version, m.Latest())
+	}
+	return nil
+}
+
+func (m *Migrator) Status() ([]*MigrationStatus, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+	appliedAt := make(map[int]time.Time, len(applied))
+	for _, a := range applied {
+		appliedAt[a.Version] = a.AppliedAt
+	}
+
+	status := make([]*MigrationStatus, 0, len(m.migrations))
+	for _, mig := range m.migrations {
+		s := &MigrationStatus{Version: mig.Version, Name: mig.Name}
+		if t, ok := appliedAt[mig.Version]; ok {
+			s.AppliedAt = &t
+		}
+		status = append(status, s)
+	}
+	return status, nil
+}
+
+// 여러 instance 가 동시에 시작해도 migration 이 한 번만 실행되도록 db lock 을 잡고 fn 실행
+//
+// postgres 는 advisory lock, mysql 은 GET_LOCK 으로 같은 connection 에서 잡고 해제
+// sqlite 는 전체를 BEGIN EXCLUSIVE transaction 으로 실행하고 migration 별 transaction 은 savepoint 가 됨
+func (m *Migrator) locked(fn func(m *Migrator) error) error {
+	return m.db.Connection(func(conn *gorm.DB) error {
+		switch m.db.Dialector.Name() {
+		case "postgres":
+			if err := conn.Exec("SELECT pg_advisory_lock(?)", migrationLockID).Error; err != nil {
+				return fmt.Errorf("lock migrations: %w", err)
+			}
+			defer conn.Exec("SELECT pg_advisory_unlock(?)", migrationLockID)
+		case "mysql":
+			// timeout 음수는 lock 을 얻을 때까지 대기
+			var got sql.NullInt64
+			if err := conn.Raw("SELECT GET_LOCK(?, -1)", migrationTable).Row().Scan(&got); err != nil {
+				return fmt.Errorf("lock migrations: %w", err)
+			}
+			if got.Int64 != 1 {
+				return errors.New("lock migrations: GET_LOCK failed")
+			}
+			defer conn.Exec("SELECT RELEASE_LOCK(?)", migrationTable)
+		case "sqlite":
+			return lockedSqlite(conn, func(tx *gorm.DB) error {
+				return fn(&Migrator{db: tx.Session(&gorm.Session{}), migrations: m.migrations})
+			})
+		}
+		return fn(&Migrator{db: conn.Session(&gorm.Session{}), migrations: m.migrations})
+	})
+}
+
+// BEGIN EXCLUSIVE 로 시작한 connection, gorm 에는 transaction 으로 보여 nested transaction 이 savepoint 가 됨
+//
+// BeginTx 가 있으면 gorm 이 Create 등에서 transaction 을 새로 시작하므로 *sql.Conn 을 embed 하지 않음
+type exclusiveTx struct {
+	conn *sql.Conn
+}
+
+func (t exclusiveTx) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return t.conn.PrepareContext(ctx, query)
+}
+
+func (t exclusiveTx) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return t.conn.ExecContext(ctx, query, args...)
+}
+
+func (t exclusiveTx) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return t.conn.QueryContext(ctx, query, args...)
+}
+
+func (t exclusiveTx) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return t.conn.QueryRowContext(ctx, query, args...)
+}
+
+func (t exclusiveTx) Commit() error {
+	_, err := t.conn.ExecContext(context.Background(), "COMMIT")
+	return err
+}
+
+func (t exclusiveTx) Rollback() error {
+	_, err := t.conn.ExecContext(context.Background(), "ROLLBACK")
+	return err
+}
+
+// 실패한 migration 은 savepoint 로 이미 되돌려졌으므로 fn 이 error 를 반환해도 앞서 적용된 migration 은 commit
+func lockedSqlite(conn *gorm.DB, fn func(tx *gorm.DB) error) error {
+	c, ok := conn.Statement.ConnPool.(*sql.Conn)
+	if !ok {
+		return fmt.Errorf("lock migrations: unexpected connection %T", conn.Statement.ConnPool)
+	}
+	if _, err := c.ExecContext(conn.Statement.Context, "BEGIN EXCLUSIVE"); err != nil {
+		return fmt.Errorf("lock migrations: %w", err)
+	}
+	tx := exclusiveTx{c}
+	conn.Statement.ConnPool = tx
+
+	panicked := true
+	defer func() {
+		if panicked {
+			tx.Rollback()
+		}
+	}()
+	err := fn(conn)
+	panicked = false
+
+	if commitErr := tx.Commit(); commitErr != nil {
+		tx.Rollback()
+		if err == nil {
+			err = commitErr
+		}
+	}
+	return err
+}
+
+// 적용되지 않은 migration 을 version 순서로 적용, migration 별 transaction
+func (m *Migrator) Up() error {
+	return m.locked((*Migrator).up)
+}
+
+func (m *Migrator) up() error {
+	if err := m.Check(); err != nil {
+		return err
+	}
+	if err := m.adoptLegacySchema(); err != nil {
+		return err
+	}
+
+	applied, err := m.applied()
+	if err != nil {
+		return err
+	}
+	done := make(map[int]bool, len(applied))
+	for _, a := range applied {
+		done[a.Version] = true
+	}
+
+	for _, mig := range m.migrations {
+		if done[mig.Version] {
+			continue
+		}
+		err := m.db.Transaction(func(tx *gorm.DB) error {
+			for _, statement := range splitStatements(mig.Up) {
+				if err := tx.Exec(statement).Error; err != nil {
+					return err
+				}
+			}
+			return tx.Create(&SchemaMigration{Version: mig.Version, Name: mig.Name, AppliedAt: time.Now()}).Error
+		})
+		if err != nil {
+			return fmt.Errorf("migration %d_%s up: %w", mig.Version, mig.Name, err)
+		}
+	}
+	return nil
+}
+
+// 마지막으로 적용된 migration 부터 steps 개 되돌림
+func (m *Migrator) Down(steps int) error {
+	return m.locked(func(m *Migrator) error { return m.down(steps) })
+}
+
+func (m *Migrator) down(steps int) error {
+	if err := m.Check(); err != nil {
+		return err
+	}
+
+	applied, err := m.applied()
+	if err != nil {
+		return err
+	}
+	byVersion := make(map[int]*migration, len(m.migrations))
+	for _, mig := range m.migrations {
+		byVersion[mig.Version] = mig
+	}
+
+	for i := len(applied) - 1; i >= 0 && steps > 0; i, steps = i-1, steps-1 {
+		mig := byVersion[applied[i].Version]
+		if mig == nil || mig.Down == "" {
+			return fmt.Errorf("migration %d can not be rolled back", applied[i].Version)
+		}
+		err := m.db.Transaction(func(tx *gorm.DB) error {
+			for _, statement := range splitStatements(mig.Down) {
+				if err := tx.Exec(statement).Error; err != nil {
+					return err
+				}
+			}
+			return tx.Delete(&SchemaMigration{}, mig.Version).Error
+		})
+		if err != nil {
+			return fmt.Errorf("migration %d_%s down: %w", mig.Version, mig.Name, err)
+		}
+	}
+	return nil
+}
+
+// migration 도입 전 AutoMigrate 로 생성된 db 는 version 1 의 구조로 맞춘 후 version 1 로 기록
+func (m *Migrator) adoptLegacySchema() error {
+	if m.db.Migrator().HasTable(migrationTable) || !m.db.Migrator().HasTable("users") {
+		return m.db.AutoMigrate(&SchemaMigration{})
+	}
+
+	return m.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.AutoMigrate(&legacyUser{}, &legacyRefreshToken{}, &legacyRevokedToken{}, &legacyAPIKey{}, &SchemaMigration{}); err != nil {
+			return err
+		}
+		return tx.Create(&SchemaMigration{Version: 1, Name: m.migrations[0].Name, AppliedAt: time.Now()}).Error
+	})
+}
+
+// version 1(init) 시점의 table 구조, 기존 db 를 맞출 때만 사용하고 이후 변경은 migration 으로 추가
+type legacyUser struct {
+	gorm.Model
+	Name         string
+	Age          int
+	Birthday     time.Time
+	PasswordHash string
+	Roles        string `gorm:"type:text"`
+	ExternalID   string `gorm:"index"`
+}
+
+func (legacyUser) TableName() string { return "users" }
+
+type legacyRefreshToken struct {
+	gorm.Model
+	UserID    uint   `gorm:"index"`
+	TokenHash string `gorm:"uniqueIndex"`
+	FamilyID  string `gorm:"index"`
+	ExpiresAt time.Time
+	RevokedAt *time.Time
+}
+
+func (legacyRefreshToken) TableName() string { return "refresh_tokens" }
+
+type legacyRevokedToken struct {
+	JTI       string `gorm:"primaryKey"`
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+func (legacyRevokedToken) TableName() string { return "revoked_tokens" }
+
+type legacyAPIKey struct {
+	gorm.Model
+	UserID    uint `gorm:"index"`
+	Name      string
+	Prefix    string `gorm:"uniqueIndex"`
+	KeyHash   string
+	Scopes    string `gorm:"type:text"`
+	ExpiresAt *time.Time
+	RevokedAt *time.Time
+}
+
+func (legacyAPIKey) TableName() string { return "api_keys" }
+
+// 시작 시 schema 확인 후 적용되지 않은 migration 적용
+func migrate(db *gorm.DB) error {
+	m, err := NewMigrator(db)
+	if err != nil {
+		return err
+	}
+	return m.Up()
+}
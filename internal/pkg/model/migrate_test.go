@@ -0,0 +1,165 @@
+package model
+
+import (
+	"backend/config"
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestMigrator(t *testing.T) {
+	assert := assert.New(t)
+
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "gorm.db")), &gorm.Config{})
+	assert.NoError(err)
+
+	m, err := NewMigrator(db)
+	assert.NoError(err)
+	assert.Equal(4, m.Latest())
+
+	// 1. up
+	assert.NoError(m.Up())
+	version, err := m.Version()
+	assert.NoError(err)
+	assert.Equal(m.Latest(), version)
+	assert.True(db.Migrator().HasTable("users"))
+	assert.True(db.Migrator().HasIndex("api_keys", "idx_api_keys_prefix"))
+	assert.True(db.Migrator().HasColumn(&User{}, "Email"))
+
+	// 다시 실행해도 변경 없음
+	assert.NoError(m.Up())
+
+	status, err := m.Status()
+	assert.NoError(err)
+	assert.Equal("init", status[0].Name)
+	assert.NotNil(status[0].AppliedAt)
+
+	// 삭제되지 않은 사용자 중 name, email 고유
+	assert.NoError(db.Create(&User{Name: "a", Email: "a@example.com"}).Error)
+	assert.Error(db.Create(&User{Name: "a"}).Error)
+	assert.Error(db.Create(&User{Name: "b", Email: "a@example.com"}).Error)
+
+	// 2. down
+	assert.NoError(m.Down(1))
+	version, err = m.Version()
+	assert.NoError(err)
+	assert.Equal(3, version)
+	assert.NoError(db.Create(&User{Name: "a"}).Error)
+
+	assert.NoError(m.Down(1))
+	version, err = m.Version()
+	assert.NoError(err)
+	assert.Equal(2, version)
+	assert.NoError(db.Create(&User{Name: "b", Email: "a@example.com"}).Error)
+	assert.NoError(db.Exec("DELETE FROM users").Error)
+
+	assert.NoError(m.Down(1))
+	version, err = m.Version()
+	assert.NoError(err)
+	assert.Equal(1, version)
+	assert.False(db.Migrator().HasColumn(&User{}, "Email"))
+
+	assert.NoError(m.Down(1))
+	version, err = m.Version()
+	assert.NoError(err)
+	assert.Equal(0, version)
+	assert.False(db.Migrator().HasTable("users"))
+
+	// 3. binary 보다 새로운 schema 는 거부
+	assert.NoError(m.Up())
+	assert.NoError(db.Create(&SchemaMigration{Version: m.Latest() + 1, Name: "future", AppliedAt: time.Now()}).Error)
+	assert.ErrorIs(m.Check(), ErrSchemaTooNew)
+	assert.ErrorIs(m.Up(), ErrSchemaTooNew)
+	assert.ErrorIs(m.Down(1), ErrSchemaTooNew)
+}
+
+// migration 도입 전 AutoMigrate 로 생성된 db
+func TestMigrateLegacySchema(t *testing.T) {
+	assert := assert.New(t)
+
+	type oldUser struct {
+		gorm.Model
+		Name     string
+		Age      int
+		Birthday time.Time
+	}
+
+	path := filepath.Join(t.TempDir(), "gorm.db")
+	db, err := gorm.Open(sqlite.Open(path), &gorm.Config{})
+	assert.NoError(err)
+	assert.NoError(db.Table("users").AutoMigrate(&oldUser{}))
+	assert.NoError(db.Table("users").Create(&oldUser{Name: "a", Age: 3}).Error)
+
+	ctx := context.Background()
+	h, err := NewDBHandler(config.Config{SqliteDBPath: path})
+	assert.NoError(err)
+
+	users, err := h.GetUsers(ctx)
+	assert.NoError(err)
+	assert.Equal("a", users[0].Name)
+	assert.NoError(h.UpdateUserById(ctx, int(users[0].ID), &User{Roles: Roles{"admin"}}))
+
+	m, err := NewMigrator(db)
+	assert.NoError(err)
+	version, err := m.Version()
+	assert.NoError(err)
+	assert.Equal(m.Latest(), version)
+
+	// 기존 사용자는 active
+	assert.Equal(UserActive, users[0].Status)
+
+	// 새로운 schema 인 db 로는 시작하지 않음
+	assert.NoError(db.Create(&SchemaMigration{Version: m.Latest() + 1, Name: "future", AppliedAt: time.Now()}).Error)
+	_, err = NewDBHandler(config.Config{SqliteDBPath: path})
+	assert.ErrorIs(err, ErrSchemaTooNew)
+}
+
+// 여러 instance 가 동시에 시작해도 migration 은 한 번만 적용
+func TestMigrateConcurrently(t *testing.T) {
+	assert := assert.New(t)
+
+	path := filepath.Join(t.TempDir(), "gorm.db")
+	errs := make(chan error, 4)
+	for i := 0; i < cap(errs); i++ {
+		go func() {
+			db, err := gorm.Open(sqlite.Open(path), &gorm.Config{})
+			if err != nil {
+				errs <- err
+				return
+			}
+			m, err := NewMigrator(db)
+			if err != nil {
+				errs <- err
+				return
+			}
+			errs <- m.Up()
+		}()
+	}
+	for i := 0; i < cap(errs); i++ {
+		assert.NoError(<-errs)
+	}
+
+	db, err := gorm.Open(sqlite.Open(path), &gorm.Config{})
+	assert.NoError(err)
+	m, err := NewMigrator(db)
+	assert.NoError(err)
+	status, err := m.Status()
+	assert.NoError(err)
+	for _, s := range status {
+		assert.NotNil(s.AppliedAt)
+	}
+}
+
+func TestSplitStatements(t *testing.T) {
+	sql := `-- comment
+CREATE TABLE a (id integer);
+
+CREATE INDEX idx_a ON a(id);
+`
+	assert.Equal(t, []string{"CREATE TABLE a (id integer)", "CREATE INDEX idx_a ON a(id)"}, splitStatements(sql))
+}
@@ -0,0 +1,58 @@
+-- mysql 의 DDL 은 transaction 으로 묶이지 않으므로 실패 시 생성된 table 을 직접 정리
+CREATE TABLE `users` (
+    `id` bigint unsigned AUTO_INCREMENT,
+    `created_at` datetime(3) NULL,
+    `updated_at` datetime(3) NULL,
+    `deleted_at` datetime(3) NULL,
+    `name` varchar(191),
+    `age` bigint,
+    `birthday` datetime(3) NULL,
+    `password_hash` varchar(255),
+    `roles` text,
+    `external_id` varchar(191),
+    PRIMARY KEY (`id`),
+    INDEX `idx_users_external_id` (`external_id`),
+    INDEX `idx_users_deleted_at` (`deleted_at`)
+);
+
+CREATE TABLE `refresh_tokens` (
+    `id` bigint unsigned AUTO_INCREMENT,
+    `created_at` datetime(3) NULL,
+    `updated_at` datetime(3) NULL,
+    `deleted_at` datetime(3) NULL,
+    `user_id` bigint unsigned,
+    `token_hash` varchar(64),
+    `family_id` varchar(191),
+    `expires_at` datetime(3) NULL,
+    `revoked_at` datetime(3) NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_refresh_tokens_family_id` (`family_id`),
+    UNIQUE INDEX `idx_refresh_tokens_token_hash` (`token_hash`),
+    INDEX `idx_refresh_tokens_user_id` (`user_id`),
+    INDEX `idx_refresh_tokens_deleted_at` (`deleted_at`)
+);
+
+CREATE TABLE `revoked_tokens` (
+    `jti` varchar(191),
+    `expires_at` datetime(3) NULL,
+    `created_at` datetime(3) NULL,
+    PRIMARY KEY (`jti`)
+);
+
+CREATE TABLE `api_keys` (
+    `id` bigint unsigned AUTO_INCREMENT,
+    `created_at` datetime(3) NULL,
+    `updated_at` datetime(3) NULL,
+    `deleted_at` datetime(3) NULL,
+    `user_id` bigint unsigned,
+    `name` varchar(191),
+    `prefix` varchar(191),
+    `key_hash` varchar(64),
+    `scopes` text,
+    `expires_at` datetime(3) NULL,
+    `revoked_at` datetime(3) NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `idx_api_keys_prefix` (`prefix`),
+    INDEX `idx_api_keys_user_id` (`user_id`),
+    INDEX `idx_api_keys_deleted_at` (`deleted_at`)
+);
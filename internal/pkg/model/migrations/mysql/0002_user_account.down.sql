@@ -0,0 +1,7 @@
+ALTER TABLE `users`
+    DROP INDEX `idx_users_email`,
+    DROP INDEX `idx_users_username`,
+    DROP COLUMN `last_login_at`,
+    DROP COLUMN `status`,
+    DROP COLUMN `email`,
+    DROP COLUMN `username`;
@@ -0,0 +1,7 @@
+ALTER TABLE `users`
+    ADD COLUMN `username` varchar(191),
+    ADD COLUMN `email` varchar(191),
+    ADD COLUMN `status` varchar(16) NOT NULL DEFAULT 'active',
+    ADD COLUMN `last_login_at` datetime(3) NULL,
+    ADD INDEX `idx_users_username` (`username`),
+    ADD INDEX `idx_users_email` (`email`);
@@ -0,0 +1,7 @@
+ALTER TABLE `users`
+    DROP INDEX `idx_users_email`,
+    DROP INDEX `idx_users_username`,
+    DROP COLUMN `email_key`,
+    DROP COLUMN `username_key`,
+    ADD INDEX `idx_users_username` (`username`),
+    ADD INDEX `idx_users_email` (`email`);
@@ -0,0 +1,8 @@
+-- partial index 가 없으므로 비어있거나 삭제된 사용자는 NULL 인 generated column 에 unique index
+ALTER TABLE `users`
+    DROP INDEX `idx_users_username`,
+    DROP INDEX `idx_users_email`,
+    ADD COLUMN `username_key` varchar(191) AS (IF(`username` <> '' AND `deleted_at` IS NULL, `username`, NULL)) VIRTUAL,
+    ADD COLUMN `email_key` varchar(191) AS (IF(`email` <> '' AND `deleted_at` IS NULL, `email`, NULL)) VIRTUAL,
+    ADD UNIQUE INDEX `idx_users_username` (`username_key`),
+    ADD UNIQUE INDEX `idx_users_email` (`email_key`);
@@ -0,0 +1,4 @@
+-- login 이름, partial index 가 없으므로 삭제된 사용자는 NULL 인 generated column 에 unique index
+ALTER TABLE `users`
+    ADD COLUMN `name_key` varchar(191) AS (IF(`name` <> '' AND `deleted_at` IS NULL, `name`, NULL)) VIRTUAL,
+    ADD UNIQUE INDEX `idx_users_name` (`name_key`);
@@ -0,0 +1,57 @@
+CREATE TABLE "users" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "name" text,
+    "age" bigint,
+    "birthday" timestamptz,
+    "password_hash" text,
+    "roles" text,
+    "external_id" text,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_users_external_id" ON "users" ("external_id");
+CREATE INDEX "idx_users_deleted_at" ON "users" ("deleted_at");
+
+CREATE TABLE "refresh_tokens" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "user_id" bigint,
+    "token_hash" text,
+    "family_id" text,
+    "expires_at" timestamptz,
+    "revoked_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_refresh_tokens_family_id" ON "refresh_tokens" ("family_id");
+CREATE UNIQUE INDEX "idx_refresh_tokens_token_hash" ON "refresh_tokens" ("token_hash");
+CREATE INDEX "idx_refresh_tokens_user_id" ON "refresh_tokens" ("user_id");
+CREATE INDEX "idx_refresh_tokens_deleted_at" ON "refresh_tokens" ("deleted_at");
+
+CREATE TABLE "revoked_tokens" (
+    "jti" text,
+    "expires_at" timestamptz,
+    "created_at" timestamptz,
+    PRIMARY KEY ("jti")
+);
+
+CREATE TABLE "api_keys" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "user_id" bigint,
+    "name" text,
+    "prefix" text,
+    "key_hash" text,
+    "scopes" text,
+    "expires_at" timestamptz,
+    "revoked_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX "idx_api_keys_prefix" ON "api_keys" ("prefix");
+CREATE INDEX "idx_api_keys_user_id" ON "api_keys" ("user_id");
+CREATE INDEX "idx_api_keys_deleted_at" ON "api_keys" ("deleted_at");
@@ -0,0 +1,5 @@
+-- 비어있거나 삭제된 사용자의 값은 중복 허용
+DROP INDEX "idx_users_username";
+DROP INDEX "idx_users_email";
+CREATE UNIQUE INDEX "idx_users_username" ON "users" ("username") WHERE "username" <> '' AND "deleted_at" IS NULL;
+CREATE UNIQUE INDEX "idx_users_email" ON "users" ("email") WHERE "email" <> '' AND "deleted_at" IS NULL;
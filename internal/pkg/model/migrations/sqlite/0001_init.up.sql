@@ -0,0 +1,57 @@
+CREATE TABLE `users` (
+    `id` integer,
+    `created_at` datetime,
+    `updated_at` datetime,
+    `deleted_at` datetime,
+    `name` text,
+    `age` integer,
+    `birthday` datetime,
+    `password_hash` text,
+    `roles` text,
+    `external_id` text,
+    PRIMARY KEY (`id`)
+);
+CREATE INDEX `idx_users_external_id` ON `users`(`external_id`);
+CREATE INDEX `idx_users_deleted_at` ON `users`(`deleted_at`);
+
+CREATE TABLE `refresh_tokens` (
+    `id` integer,
+    `created_at` datetime,
+    `updated_at` datetime,
+    `deleted_at` datetime,
+    `user_id` integer,
+    `token_hash` text,
+    `family_id` text,
+    `expires_at` datetime,
+    `revoked_at` datetime,
+    PRIMARY KEY (`id`)
+);
+CREATE INDEX `idx_refresh_tokens_family_id` ON `refresh_tokens`(`family_id`);
+CREATE UNIQUE INDEX `idx_refresh_tokens_token_hash` ON `refresh_tokens`(`token_hash`);
+CREATE INDEX `idx_refresh_tokens_user_id` ON `refresh_tokens`(`user_id`);
+CREATE INDEX `idx_refresh_tokens_deleted_at` ON `refresh_tokens`(`deleted_at`);
+
+CREATE TABLE `revoked_tokens` (
+    `jti` text,
+    `expires_at` datetime,
+    `created_at` datetime,
+    PRIMARY KEY (`jti`)
+);
+
+CREATE TABLE `api_keys` (
+    `id` integer,
+    `created_at` datetime,
+    `updated_at` datetime,
+    `deleted_at` datetime,
+    `user_id` integer,
+    `name` text,
+    `prefix` text,
+    `key_hash` text,
+    `scopes` text,
+    `expires_at` datetime,
+    `revoked_at` datetime,
+    PRIMARY KEY (`id`)
+);
+CREATE UNIQUE INDEX `idx_api_keys_prefix` ON `api_keys`(`prefix`);
+CREATE INDEX `idx_api_keys_user_id` ON `api_keys`(`user_id`);
+CREATE INDEX `idx_api_keys_deleted_at` ON `api_keys`(`deleted_at`);
@@ -0,0 +1,6 @@
+DROP INDEX `idx_users_email`;
+DROP INDEX `idx_users_username`;
+ALTER TABLE `users` DROP COLUMN `last_login_at`;
+ALTER TABLE `users` DROP COLUMN `status`;
+ALTER TABLE `users` DROP COLUMN `email`;
+ALTER TABLE `users` DROP COLUMN `username`;
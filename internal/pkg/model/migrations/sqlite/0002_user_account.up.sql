@@ -0,0 +1,6 @@
+ALTER TABLE `users` ADD COLUMN `username` text;
+ALTER TABLE `users` ADD COLUMN `email` text;
+ALTER TABLE `users` ADD COLUMN `status` text NOT NULL DEFAULT 'active';
+ALTER TABLE `users` ADD COLUMN `last_login_at` datetime;
+CREATE INDEX `idx_users_username` ON `users`(`username`);
+CREATE INDEX `idx_users_email` ON `users`(`email`);
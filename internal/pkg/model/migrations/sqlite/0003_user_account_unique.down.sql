@@ -0,0 +1,4 @@
+DROP INDEX `idx_users_username`;
+DROP INDEX `idx_users_email`;
+CREATE INDEX `idx_users_username` ON `users`(`username`);
+CREATE INDEX `idx_users_email` ON `users`(`email`);
@@ -0,0 +1,2 @@
+-- login 이름, 삭제된 사용자의 값은 중복 허용
+CREATE UNIQUE INDEX `idx_users_name` ON `users`(`name`) WHERE `name` <> '' AND `deleted_at` IS NULL;
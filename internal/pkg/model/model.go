@@ -1,34 +1,160 @@
 package model
 
 import (
-	"backend/config"
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
 )
 
+// active 가 아닌 사용자는 login, token 갱신, api key 인증 불가
+type UserStatus string
+
+const (
+	UserActive   UserStatus = "active"
+	UserDisabled UserStatus = "disabled"
+	UserLocked   UserStatus = "locked"
+)
+
+func (s UserStatus) Valid() bool {
+	return s == UserActive || s == UserDisabled || s == UserLocked
+}
+
+// api 응답에는 UserResponse 사용
 type User struct {
 	gorm.Model
-	Name     string    `json:"name"`
-	Age      int       `json:"age"`
-	Birthday time.Time `json:"birthday"`
+	// login 에 사용하는 이름, 삭제되지 않은 사용자 중 고유(partial unique index)
+	Name string `json:"name" gorm:"index"`
+	// 입력 시 삭제되지 않은 사용자 중 고유(partial unique index), 영문/숫자/. _ - 3~32자
+	Username string `json:"username" gorm:"index"`
+	// 입력 시 삭제되지 않은 사용자 중 고유(partial unique index), 소문자로 저장
+	Email        string     `json:"email" gorm:"index"`
+	Age          int        `json:"age"`
+	Birthday     time.Time  `json:"birthday"`
+	PasswordHash string     `json:"-"`
+	Status       UserStatus `json:"status"`
+	// admin, developer, viewer, 비어있으면 viewer 로 취급
+	Roles       Roles      `json:"roles" gorm:"type:text"`
+	LastLoginAt *time.Time `json:"lastLoginAt"`
+	// 외부 idp(oidc) 사용자, "issuer|subject" 형태
+	ExternalID string `json:"-" gorm:"index"`
 }
 
-type DBHandler interface {
-	GetUsers() []*User
-	AddUser(user *User) int64
-	GetUserById(id int) *User
-	DeleteUserById(id int) int64
-	UpdateUserById(id int, user *User) int64
-}
-
-func NewDBHandler(cfg config.Config) DBHandler {
-	if cfg.DB == "postgre" {
-		return NewPostgreHandler(cfg)
-	} else if cfg.DB == "sqlite" {
-		return NewSqliteHandler(cfg)
-	} else {
-		return NewSqliteHandler(cfg)
+// status 미설정(migration 이전 생성) 사용자는 active
+func (u *User) Active() bool {
+	return u.Status == "" || u.Status == UserActive
+}
+
+// db 에는 "admin,developer" 형태로 저장
+type Roles []string
+
+func (r Roles) Value() (driver.Value, error) {
+	return strings.Join(r, ","), nil
+}
+
+func (r *Roles) Scan(value interface{}) error {
+	var s string
+	switch v := value.(type) {
+	case nil:
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return fmt.Errorf("unsupported roles type %T", value)
+	}
+
+	*r = nil
+	for _, role := range strings.Split(s, ",") {
+		if role = strings.TrimSpace(role); role != "" {
+			*r = append(*r, role)
+		}
 	}
+	return nil
+}
+
+func (r Roles) Has(role string) bool {
+	for _, v := range r {
+		if v == role {
+			return true
+		}
+	}
+	return false
+}
+
+// refresh token 원문은 저장하지 않고 hash 만 저장
+//
+// 같은 login 에서 rotation 으로 이어진 token 은 같은 FamilyID 를 가짐
+type RefreshToken struct {
+	gorm.Model
+	UserID    uint   `gorm:"index"`
+	TokenHash string `gorm:"uniqueIndex"`
+	FamilyID  string `gorm:"index"`
+	ExpiresAt time.Time
+	RevokedAt *time.Time
+}
+
+// 만료 전에 폐기된 access token 의 jti
+type RevokedToken struct {
+	JTI       string `gorm:"primaryKey"`
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+// 기계 사용자(CI 등)용 api key, 원문은 발급 시에만 반환하고 hash 만 저장
+type APIKey struct {
+	gorm.Model
+	UserID uint   `json:"userId" gorm:"index"`
+	Name   string `json:"name"`
+	// 조회용 key 앞부분, 원문 "ak_<prefix>_<secret>"
+	Prefix    string     `json:"prefix" gorm:"uniqueIndex"`
+	KeyHash   string     `json:"-"`
+	Scopes    Scopes     `json:"scopes" gorm:"type:text"`
+	ExpiresAt *time.Time `json:"expiresAt"`
+	RevokedAt *time.Time `json:"revokedAt"`
+}
+
+// api key 로 허용할 권한 (repo:read 등), Roles 와 같은 형태로 저장
+type Scopes []string
+
+func (s Scopes) Value() (driver.Value, error) {
+	return Roles(s).Value()
+}
+
+func (s *Scopes) Scan(value interface{}) error {
+	return (*Roles)(s).Scan(value)
+}
+
+// 없는 행은 ErrNotFound, unique 위반은 ErrConflict, 잘못된 입력은 ErrValidation 반환
+//
+// ctx 가 취소되거나 cfg.Timeouts.DB 를 넘기면 query 중단
+type DBHandler interface {
+	GetUsers(ctx context.Context) ([]*User, error)
+	ListUsers(ctx context.Context, query *UserQuery) (*UserPage, error)
+	AddUser(ctx context.Context, user *User) error
+	GetUserById(ctx context.Context, id int) (*User, error)
+	GetUserByName(ctx context.Context, name string) (*User, error)
+	GetUserByExternalID(ctx context.Context, externalID string) (*User, error)
+	DeleteUserById(ctx context.Context, id int) error
+	UpdateUserById(ctx context.Context, id int, user *User) error
+	UpdateLastLogin(ctx context.Context, id uint) error
+
+	AddRefreshToken(ctx context.Context, token *RefreshToken) error
+	GetRefreshTokenByHash(ctx context.Context, hash string) (*RefreshToken, error)
+	RevokeRefreshToken(ctx context.Context, id uint) error
+	RevokeRefreshTokenFamily(ctx context.Context, familyID string) error
+	RevokeUserRefreshTokens(ctx context.Context, userID uint) error
+	AddRevokedToken(ctx context.Context, token *RevokedToken) error
+	IsTokenRevoked(ctx context.Context, jti string) (bool, error)
+
+	AddAPIKey(ctx context.Context, key *APIKey) error
+	GetAPIKeyByPrefix(ctx context.Context, prefix string) (*APIKey, error)
+	GetAPIKeysByUserId(ctx context.Context, userID uint) ([]*APIKey, error)
+	RevokeAPIKey(ctx context.Context, id uint) error
 
+	Ping(ctx context.Context) error
+	Close() error
 }
@@ -0,0 +1,51 @@
+package model
+
+import (
+	"backend/config"
+	"errors"
+	"net"
+	"strings"
+	"time"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func init() {
+	RegisterDialect("mysql", &Dialect{
+		Open: func(cfg config.Config) gorm.Dialector {
+			return mysql.Open(mysqlDSN(cfg.MySQL))
+		},
+		UniqueViolation: mysqlUniqueViolation,
+	})
+}
+
+// 시간 column 을 time.Time 으로 읽도록 parseTime 설정
+func mysqlDSN(cfg config.MySQL) string {
+	c := mysqldriver.NewConfig()
+	c.User = cfg.User
+	c.Passwd = cfg.Password
+	c.Net = "tcp"
+	c.Addr = net.JoinHostPort(cfg.Host, cfg.Port)
+	c.DBName = cfg.DBName
+	c.ParseTime = true
+	c.Loc = time.Local
+	c.Params = map[string]string{"charset": cfg.Charset}
+	return c.FormatDSN()
+}
+
+// ER_DUP_ENTRY(1062), primary key 포함
+//
+// "Duplicate entry 'a' for key 'users.idx_users_email'" 의 key 이름 반환
+func mysqlUniqueViolation(err error) (string, bool) {
+	var e *mysqldriver.MySQLError
+	if !errors.As(err, &e) || e.Number != 1062 {
+		return "", false
+	}
+	i := strings.LastIndex(e.Message, "for key '")
+	if i < 0 {
+		return "", true
+	}
+	return strings.TrimSuffix(e.Message[i+len("for key '"):], "'"), true
+}
@@ -0,0 +1,59 @@
+package model
+
+import (
+	"backend/config"
+	"errors"
+	"net"
+	"net/url"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func init() {
+	RegisterDialect("postgre", &Dialect{
+		Open: func(cfg config.Config) gorm.Dialector {
+			return postgres.New(postgres.Config{
+				DSN:                  postgreDSN(cfg.Postgre),
+				PreferSimpleProtocol: true, // disables implicit prepared statement usage
+			})
+		},
+		UniqueViolation: postgreUniqueViolation,
+	})
+}
+
+// url 형태로 만들어 password 등의 특수 문자 escape, 비어있는 값은 driver 기본값 사용
+func postgreDSN(cfg config.Postgre) string {
+	u := &url.URL{
+		Scheme: "postgres",
+		Host:   cfg.IP,
+		Path:   "/" + cfg.DBName,
+	}
+	if cfg.Port != "" {
+		u.Host = net.JoinHostPort(cfg.IP, cfg.Port)
+	}
+	if cfg.User != "" {
+		u.User = url.UserPassword(cfg.User, cfg.Password)
+	}
+
+	params := url.Values{}
+	if cfg.SSLMode != "" {
+		params.Set("sslmode", cfg.SSLMode)
+	}
+	if cfg.TimeZone != "" {
+		params.Set("TimeZone", cfg.TimeZone)
+	}
+	u.RawQuery = params.Encode()
+
+	return u.String()
+}
+
+// unique_violation(23505), primary key 포함, 위반한 constraint 이름 반환
+func postgreUniqueViolation(err error) (string, bool) {
+	var e *pgconn.PgError
+	if !errors.As(err, &e) || e.Code != "23505" {
+		return "", false
+	}
+	return e.ConstraintName, true
+}
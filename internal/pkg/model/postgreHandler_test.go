@@ -2,6 +2,7 @@ package model
 
 import (
 	"backend/config"
+	"context"
 	"testing"
 	"time"
 
@@ -11,8 +12,6 @@ import (
 func TestPostgreHandler(t *testing.T) {
 	assert := assert.New(t)
 
-	var cnt int64
-
 	cfg := config.Config{
 		Postgre: config.Postgre{
 			IP:       "127.0.0.1",
@@ -23,42 +22,61 @@ func TestPostgreHandler(t *testing.T) {
 			SSLMode:  "disable",
 			TimeZone: "Asia/Seoul",
 		},
+		DB: "postgre",
+	}
+	ctx := context.Background()
+	h, err := NewDBHandler(cfg)
+	if !assert.NoError(err) {
+		return
 	}
-	h := NewPostgreHandler(cfg)
 
-	cnt = h.AddUser(&User{Name: "a", Age: 38, Birthday: time.Now()})
-	assert.Equal(1, int(cnt))
+	err = h.AddUser(ctx, &User{Name: "a", Age: 38, Birthday: time.Now().AddDate(-38, 0, -1)})
+	assert.NoError(err)
 
-	users := h.GetUsers()
+	users, err := h.GetUsers(ctx)
+	assert.NoError(err)
 	assert.Equal("a", users[0].Name)
 	assert.Equal(38, users[0].Age)
 
-	cnt = h.AddUser(&User{Name: "b", Age: 38, Birthday: time.Now()})
-	assert.Equal(1, int(cnt))
-	cnt = h.AddUser(&User{Name: "c", Age: 38, Birthday: time.Now()})
-	assert.Equal(1, int(cnt))
+	assert.NoError(h.AddUser(ctx, &User{Name: "b", Age: 38, Birthday: time.Now().AddDate(-38, 0, -1)}))
+	assert.NoError(h.AddUser(ctx, &User{Name: "c", Age: 38, Birthday: time.Now().AddDate(-38, 0, -1)}))
 
-	users = h.GetUsers()
+	// 같은 이름, 잘못된 입력
+	assert.ErrorIs(h.AddUser(ctx, &User{Name: "c"}), ErrConflict)
+	assert.ErrorIs(h.AddUser(ctx, &User{Age: 1}), ErrValidation)
+
+	users, err = h.GetUsers(ctx)
+	assert.NoError(err)
 	assert.Equal(3, len(users))
 
-	user := h.GetUserById(1)
+	user, err := h.GetUserById(ctx, 1)
+	assert.NoError(err)
 	assert.Equal("a", user.Name)
 
-	cnt = h.DeleteUserById(1)
-	assert.Equal(1, int(cnt))
+	assert.NoError(h.DeleteUserById(ctx, 1))
+	assert.ErrorIs(h.DeleteUserById(ctx, 1), ErrNotFound)
 
-	users = h.GetUsers()
+	users, err = h.GetUsers(ctx)
+	assert.NoError(err)
 	assert.Equal(2, len(users))
 
-	user = h.GetUserById(1)
-	assert.Equal(uint(0), user.ID)
-	assert.Equal("", user.Name)
+	user, err = h.GetUserById(ctx, 1)
+	assert.ErrorIs(err, ErrNotFound)
+	assert.Nil(user)
 
-	cnt = h.UpdateUserById(2, &User{Name: "bbb", Age: 40, Birthday: time.Now()})
-	assert.Equal(1, int(cnt))
+	assert.NoError(h.UpdateUserById(ctx, 2, &User{Name: "bbb", Age: 40, Birthday: time.Now().AddDate(-40, 0, -1)}))
+	assert.ErrorIs(h.UpdateUserById(ctx, 2, &User{Name: "c"}), ErrConflict)
+	assert.ErrorIs(h.UpdateUserById(ctx, 1, &User{Name: "d"}), ErrNotFound)
 
-	user = h.GetUserById(2)
+	user, err = h.GetUserById(ctx, 2)
+	assert.NoError(err)
 	assert.Equal("bbb", user.Name)
 	assert.Equal(40, user.Age)
 
+	_, err = h.GetUserByName(ctx, "a")
+	assert.ErrorIs(err, ErrNotFound)
+
+	// unique 제약 위반
+	assert.NoError(h.AddAPIKey(ctx, &APIKey{UserID: 2, Name: "ci", Prefix: "0011aabb"}))
+	assert.ErrorIs(h.AddAPIKey(ctx, &APIKey{UserID: 2, Name: "ci", Prefix: "0011aabb"}), ErrConflict)
 }
@@ -0,0 +1,288 @@
+package model
+
+import (
+	"backend/internal/pkg/ctxutil"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// dialect 와 관계없이 같은 gorm 호출을 사용하는 DBHandler 구현
+type gormRepository struct {
+	db          *gorm.DB
+	dialect     *Dialect
+	timeout     time.Duration
+	pingTimeout time.Duration
+}
+
+func (r *gormRepository) translate(err error) error {
+	return dbError(err, r.dialect.UniqueViolation)
+}
+
+// ctx 와 호출 1건의 제한 시간을 적용한 session, 호출이 끝나면 cancel
+func (r *gormRepository) session(ctx context.Context) (*gorm.DB, context.CancelFunc) {
+	ctx, cancel := ctxutil.WithTimeout(ctx, r.timeout)
+	return r.db.WithContext(ctx), cancel
+}
+
+// readiness 확인용, pingTimeout 안에 응답이 없으면 error
+func (r *gormRepository) Ping(ctx context.Context) error {
+	sqlDB, err := r.db.DB()
+	if err != nil {
+		return err
+	}
+	return ping(ctx, sqlDB, r.pingTimeout)
+}
+
+func (r *gormRepository) Close() error {
+	sqlDB, err := r.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
+
+// status 미입력 시 active, age 미입력 시 birthday 로 계산
+func (r *gormRepository) AddUser(ctx context.Context, user *User) error {
+	user.normalize()
+	if err := user.validate(true); err != nil {
+		return err
+	}
+	if err := user.checkAge(nil); err != nil {
+		return err
+	}
+	if user.Status == "" {
+		user.Status = UserActive
+	}
+
+	db, cancel := r.session(ctx)
+	defer cancel()
+
+	return r.translate(db.Create(user).Error)
+}
+
+func (r *gormRepository) GetUsers(ctx context.Context) ([]*User, error) {
+	db, cancel := r.session(ctx)
+	defer cancel()
+
+	var users []*User
+	if err := db.Find(&users).Error; err != nil {
+		return nil, r.translate(err)
+	}
+	return users, nil
+}
+
+// 조건에 맞는 사용자 한 페이지와 전체 개수
+func (r *gormRepository) ListUsers(ctx context.Context, query *UserQuery) (*UserPage, error) {
+	db, cancel := r.session(ctx)
+	defer cancel()
+
+	page, err := listUsers(db, query)
+	return page, r.translate(err)
+}
+
+func (r *gormRepository) GetUserById(ctx context.Context, id int) (*User, error) {
+	db, cancel := r.session(ctx)
+	defer cancel()
+
+	var user User
+	if err := db.First(&user, id).Error; err != nil {
+		return nil, r.translate(err)
+	}
+	return &user, nil
+}
+
+func (r *gormRepository) GetUserByName(ctx context.Context, name string) (*User, error) {
+	db, cancel := r.session(ctx)
+	defer cancel()
+
+	var user User
+	if err := db.Where("name = ?", name).First(&user).Error; err != nil {
+		return nil, r.translate(err)
+	}
+	return &user, nil
+}
+
+func (r *gormRepository) GetUserByExternalID(ctx context.Context, externalID string) (*User, error) {
+	db, cancel := r.session(ctx)
+	defer cancel()
+
+	var user User
+	if err := db.Where("external_id = ?", externalID).First(&user).Error; err != nil {
+		return nil, r.translate(err)
+	}
+	return &user, nil
+}
+
+func (r *gormRepository) DeleteUserById(ctx context.Context, id int) error {
+	db, cancel := r.session(ctx)
+	defer cancel()
+
+	result := db.Delete(&User{}, id)
+	if result.Error != nil {
+		return r.translate(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// user 의 zero value 가 아닌 field 만 변경
+func (r *gormRepository) UpdateUserById(ctx context.Context, id int, user *User) error {
+	user.normalize()
+	if err := user.validate(false); err != nil {
+		return err
+	}
+
+	db, cancel := r.session(ctx)
+	defer cancel()
+
+	var originUser User
+	if err := db.First(&originUser, id).Error; err != nil {
+		return r.translate(err)
+	}
+	if err := user.checkAge(&originUser); err != nil {
+		return err
+	}
+	return r.translate(db.Model(&originUser).Updates(user).Error)
+}
+
+// login 성공 시각 기록, updated_at 은 변경하지 않음
+func (r *gormRepository) UpdateLastLogin(ctx context.Context, id uint) error {
+	db, cancel := r.session(ctx)
+	defer cancel()
+
+	result := db.Model(&User{}).Where("id = ?", id).UpdateColumn("last_login_at", time.Now())
+	if result.Error != nil {
+		return r.translate(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *gormRepository) AddRefreshToken(ctx context.Context, token *RefreshToken) error {
+	db, cancel := r.session(ctx)
+	defer cancel()
+
+	return r.translate(db.Create(token).Error)
+}
+
+func (r *gormRepository) GetRefreshTokenByHash(ctx context.Context, hash string) (*RefreshToken, error) {
+	db, cancel := r.session(ctx)
+	defer cancel()
+
+	var token RefreshToken
+	if err := db.Where("token_hash = ?", hash).First(&token).Error; err != nil {
+		return nil, r.translate(err)
+	}
+	return &token, nil
+}
+
+// 이미 폐기된 token 이면 ErrConflict, rotation 시 동시 요청 중 하나만 성공
+func (r *gormRepository) RevokeRefreshToken(ctx context.Context, id uint) error {
+	db, cancel := r.session(ctx)
+	defer cancel()
+
+	result := db.Model(&RefreshToken{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return r.translate(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrConflict
+	}
+	return nil
+}
+
+func (r *gormRepository) RevokeRefreshTokenFamily(ctx context.Context, familyID string) error {
+	db, cancel := r.session(ctx)
+	defer cancel()
+
+	result := db.Model(&RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", time.Now())
+	return r.translate(result.Error)
+}
+
+func (r *gormRepository) RevokeUserRefreshTokens(ctx context.Context, userID uint) error {
+	db, cancel := r.session(ctx)
+	defer cancel()
+
+	result := db.Model(&RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now())
+	return r.translate(result.Error)
+}
+
+// 만료된 jti 는 더 이상 확인할 필요가 없으므로 함께 정리
+func (r *gormRepository) AddRevokedToken(ctx context.Context, token *RevokedToken) error {
+	db, cancel := r.session(ctx)
+	defer cancel()
+
+	if err := db.Where("expires_at < ?", time.Now()).Delete(&RevokedToken{}).Error; err != nil {
+		return r.translate(err)
+	}
+	return r.translate(db.Create(token).Error)
+}
+
+func (r *gormRepository) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	db, cancel := r.session(ctx)
+	defer cancel()
+
+	var count int64
+	if err := db.Model(&RevokedToken{}).Where("jti = ?", jti).Count(&count).Error; err != nil {
+		return false, r.translate(err)
+	}
+	return count > 0, nil
+}
+
+func (r *gormRepository) AddAPIKey(ctx context.Context, key *APIKey) error {
+	db, cancel := r.session(ctx)
+	defer cancel()
+
+	return r.translate(db.Create(key).Error)
+}
+
+func (r *gormRepository) GetAPIKeyByPrefix(ctx context.Context, prefix string) (*APIKey, error) {
+	db, cancel := r.session(ctx)
+	defer cancel()
+
+	var key APIKey
+	if err := db.Where("prefix = ?", prefix).First(&key).Error; err != nil {
+		return nil, r.translate(err)
+	}
+	return &key, nil
+}
+
+func (r *gormRepository) GetAPIKeysByUserId(ctx context.Context, userID uint) ([]*APIKey, error) {
+	db, cancel := r.session(ctx)
+	defer cancel()
+
+	var keys []*APIKey
+	if err := db.Where("user_id = ?", userID).Order("id").Find(&keys).Error; err != nil {
+		return nil, r.translate(err)
+	}
+	return keys, nil
+}
+
+// 이미 폐기된 key 면 ErrConflict
+func (r *gormRepository) RevokeAPIKey(ctx context.Context, id uint) error {
+	db, cancel := r.session(ctx)
+	defer cancel()
+
+	result := db.Model(&APIKey{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return r.translate(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrConflict
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package model
+
+import (
+	"backend/config"
+	"errors"
+	"strings"
+
+	"github.com/mattn/go-sqlite3"
+	"gorm.io/driver/sqlite" // Sqlite driver based on GGO
+	// "github.com/glebarez/sqlite" // Pure go SQLite driver, checkout https://github.com/glebarez/sqlite for details
+	"gorm.io/gorm"
+)
+
+func init() {
+	RegisterDialect("sqlite", &Dialect{
+		Open: func(cfg config.Config) gorm.Dialector {
+			return sqlite.Open(cfg.SqliteDBPath)
+		},
+		UniqueViolation: sqliteUniqueViolation,
+	})
+}
+
+// unique, primary key 제약 위반, "UNIQUE constraint failed: users.email" 의 column 반환
+func sqliteUniqueViolation(err error) (string, bool) {
+	var e sqlite3.Error
+	if !errors.As(err, &e) ||
+		(e.ExtendedCode != sqlite3.ErrConstraintUnique && e.ExtendedCode != sqlite3.ErrConstraintPrimaryKey) {
+		return "", false
+	}
+	_, columns, _ := strings.Cut(e.Error(), "failed: ")
+	column, _, _ := strings.Cut(columns, ",")
+	return column, true
+}
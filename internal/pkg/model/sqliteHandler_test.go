@@ -2,7 +2,8 @@ package model
 
 import (
 	"backend/config"
-	"os"
+	"context"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -12,47 +13,130 @@ import (
 func TestSqliteHandler(t *testing.T) {
 	assert := assert.New(t)
 
-	var cnt int64
-
-	os.Remove("./gorm.db")
 	cfg := config.Config{
-		SqliteDBPath: "./gorm.db",
+		SqliteDBPath: filepath.Join(t.TempDir(), "gorm.db"),
 	}
-	h := NewSqliteHandler(cfg)
+	ctx := context.Background()
+	h, err := NewDBHandler(cfg)
+	assert.NoError(err)
 
-	cnt = h.AddUser(&User{Name: "a", Age: 38, Birthday: time.Now()})
-	assert.Equal(1, int(cnt))
+	err = h.AddUser(ctx, &User{Name: "a", Age: 38, Birthday: time.Now().AddDate(-38, 0, -1)})
+	assert.NoError(err)
 
-	users := h.GetUsers()
+	users, err := h.GetUsers(ctx)
+	assert.NoError(err)
 	assert.Equal("a", users[0].Name)
 	assert.Equal(38, users[0].Age)
 
-	cnt = h.AddUser(&User{Name: "b", Age: 38, Birthday: time.Now()})
-	assert.Equal(1, int(cnt))
-	cnt = h.AddUser(&User{Name: "c", Age: 38, Birthday: time.Now()})
-	assert.Equal(1, int(cnt))
+	assert.NoError(h.AddUser(ctx, &User{Name: "b", Age: 38, Birthday: time.Now().AddDate(-38, 0, -1)}))
+	assert.NoError(h.AddUser(ctx, &User{Name: "c", Age: 38, Birthday: time.Now().AddDate(-38, 0, -1)}))
+
+	// 같은 이름, 잘못된 입력
+	assert.EqualError(h.AddUser(ctx, &User{Name: "c"}), "conflict: name already exists")
+	assert.ErrorIs(h.AddUser(ctx, &User{Age: 1}), ErrValidation)
 
-	users = h.GetUsers()
+	users, err = h.GetUsers(ctx)
+	assert.NoError(err)
 	assert.Equal(3, len(users))
 
-	user := h.GetUserById(1)
+	user, err := h.GetUserById(ctx, 1)
+	assert.NoError(err)
 	assert.Equal("a", user.Name)
 
-	cnt = h.DeleteUserById(1)
-	assert.Equal(1, int(cnt))
+	assert.NoError(h.DeleteUserById(ctx, 1))
+	assert.ErrorIs(h.DeleteUserById(ctx, 1), ErrNotFound)
 
-	users = h.GetUsers()
+	users, err = h.GetUsers(ctx)
+	assert.NoError(err)
 	assert.Equal(2, len(users))
 
-	user = h.GetUserById(1)
-	assert.Equal(uint(0), user.ID)
-	assert.Equal("", user.Name)
+	user, err = h.GetUserById(ctx, 1)
+	assert.ErrorIs(err, ErrNotFound)
+	assert.Nil(user)
 
-	cnt = h.UpdateUserById(2, &User{Name: "bbb", Age: 40, Birthday: time.Now()})
-	assert.Equal(1, int(cnt))
+	assert.NoError(h.UpdateUserById(ctx, 2, &User{Name: "bbb", Age: 40, Birthday: time.Now().AddDate(-40, 0, -1)}))
+	assert.ErrorIs(h.UpdateUserById(ctx, 2, &User{Name: "c"}), ErrConflict)
+	assert.ErrorIs(h.UpdateUserById(ctx, 1, &User{Name: "d"}), ErrNotFound)
 
-	user = h.GetUserById(2)
+	user, err = h.GetUserById(ctx, 2)
+	assert.NoError(err)
 	assert.Equal("bbb", user.Name)
 	assert.Equal(40, user.Age)
 
+	_, err = h.GetUserByName(ctx, "a")
+	assert.ErrorIs(err, ErrNotFound)
+
+	// unique 제약 위반
+	assert.NoError(h.AddAPIKey(ctx, &APIKey{UserID: 2, Name: "ci", Prefix: "0011aabb"}))
+	err = h.AddAPIKey(ctx, &APIKey{UserID: 2, Name: "ci", Prefix: "0011aabb"})
+	assert.ErrorIs(err, ErrConflict)
+	// driver error 는 응답에 포함하지 않음
+	assert.EqualError(err, "conflict: prefix already exists")
+
+	// 취소된 request 의 query 는 실행하지 않음
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = h.GetUsers(canceled)
+	assert.ErrorIs(err, context.Canceled)
+}
+
+func TestUserAccount(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx := context.Background()
+	h, err := NewDBHandler(config.Config{SqliteDBPath: t.TempDir() + "/gorm.db"})
+	if !assert.NoError(err) {
+		return
+	}
+
+	// 입력값 확인
+	assert.ErrorIs(h.AddUser(ctx, &User{Name: "a", Email: "not an email"}), ErrValidation)
+	assert.ErrorIs(h.AddUser(ctx, &User{Name: "a", Username: "a b"}), ErrValidation)
+	assert.ErrorIs(h.AddUser(ctx, &User{Name: "a", Status: "deleted"}), ErrValidation)
+	assert.ErrorIs(h.AddUser(ctx, &User{Name: "a", Birthday: time.Now().AddDate(0, 0, 1)}), ErrValidation)
+	assert.ErrorIs(h.AddUser(ctx, &User{Name: "a", Age: 20, Birthday: time.Now().AddDate(-30, 0, -1)}), ErrValidation)
+
+	// status 기본값 active, age 는 birthday 로 계산, email 은 소문자로 저장
+	user := &User{Name: "a", Username: "kim.a", Email: " Kim@Example.com ", Birthday: time.Now().AddDate(-30, 0, -1)}
+	assert.NoError(h.AddUser(ctx, user))
+	stored, err := h.GetUserById(ctx, int(user.ID))
+	if assert.NoError(err) {
+		assert.Equal(UserActive, stored.Status)
+		assert.Equal(30, stored.Age)
+		assert.Equal("kim@example.com", stored.Email)
+		assert.Nil(stored.LastLoginAt)
+	}
+
+	// username, email 중복은 unique index 로 확인
+	assert.EqualError(h.AddUser(ctx, &User{Name: "b", Username: "kim.a"}), "conflict: username already exists")
+	assert.EqualError(h.AddUser(ctx, &User{Name: "b", Email: "KIM@example.com"}), "conflict: email already exists")
+	assert.NoError(h.AddUser(ctx, &User{Name: "b", Email: "b@example.com"}))
+	assert.ErrorIs(h.UpdateUserById(ctx, int(user.ID), &User{Email: "b@example.com"}), ErrConflict)
+
+	// 비어있는 값은 중복 허용, 삭제된 사용자의 값은 재사용 가능
+	other := &User{Name: "c", Username: "kim.c", Email: "c@example.com"}
+	assert.NoError(h.AddUser(ctx, other))
+	assert.NoError(h.DeleteUserById(ctx, int(other.ID)))
+	assert.NoError(h.AddUser(ctx, &User{Name: "d", Username: "kim.c", Email: "c@example.com"}))
+
+	// 수정 시 저장된 birthday 와 age 비교
+	assert.ErrorIs(h.UpdateUserById(ctx, int(user.ID), &User{Age: 31}), ErrValidation)
+	assert.NoError(h.UpdateUserById(ctx, int(user.ID), &User{Status: UserDisabled}))
+
+	assert.NoError(h.UpdateLastLogin(ctx, user.ID))
+	assert.ErrorIs(h.UpdateLastLogin(ctx, 100), ErrNotFound)
+
+	stored, err = h.GetUserById(ctx, int(user.ID))
+	if assert.NoError(err) {
+		assert.False(stored.Active())
+		assert.NotNil(stored.LastLoginAt)
+	}
+
+	// 응답에는 내부 field 제외
+	page, err := h.ListUsers(ctx, &UserQuery{Status: UserDisabled})
+	if assert.NoError(err) && assert.Equal(int64(1), page.Total) {
+		assert.Equal("kim.a", page.Items[0].Username)
+		assert.Equal(UserDisabled, page.Items[0].Status)
+		assert.Equal(30, page.Items[0].Age)
+	}
 }
@@ -0,0 +1,83 @@
+package model
+
+import "time"
+
+// 사용자 생성/수정 요청 body
+//
+// 생성 시 name 필수, 수정 시에는 입력된 field 만 변경
+type UserRequest struct {
+	Name     string     `json:"name"`
+	Username string     `json:"username"`
+	Email    string     `json:"email"`
+	Password string     `json:"password"`
+	Age      int        `json:"age"`
+	Birthday *time.Time `json:"birthday"`
+	// active, disabled, locked
+	Status UserStatus `json:"status"`
+	Roles  Roles      `json:"roles"`
+}
+
+// password 는 hash 후 PasswordHash 로 따로 설정
+func (r *UserRequest) User() *User {
+	user := &User{
+		Name:     r.Name,
+		Username: r.Username,
+		Email:    r.Email,
+		Age:      r.Age,
+		Status:   r.Status,
+		Roles:    r.Roles,
+	}
+	if r.Birthday != nil {
+		user.Birthday = *r.Birthday
+	}
+	return user
+}
+
+// password hash, 외부 idp id 등 내부 field 는 제외
+type UserResponse struct {
+	ID          uint       `json:"id"`
+	Name        string     `json:"name"`
+	Username    string     `json:"username,omitempty"`
+	Email       string     `json:"email,omitempty"`
+	Age         int        `json:"age"`
+	Birthday    *time.Time `json:"birthday,omitempty"`
+	Status      UserStatus `json:"status"`
+	Roles       Roles      `json:"roles"`
+	LastLoginAt *time.Time `json:"lastLoginAt,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+}
+
+func NewUserResponse(user *User) *UserResponse {
+	res := &UserResponse{
+		ID:          user.ID,
+		Name:        user.Name,
+		Username:    user.Username,
+		Email:       user.Email,
+		Age:         user.Age,
+		Status:      user.Status,
+		Roles:       user.Roles,
+		LastLoginAt: user.LastLoginAt,
+		CreatedAt:   user.CreatedAt,
+		UpdatedAt:   user.UpdatedAt,
+	}
+	if !user.Birthday.IsZero() {
+		birthday := user.Birthday
+		res.Birthday = &birthday
+	}
+	if res.Status == "" {
+		res.Status = UserActive
+	}
+	if res.Roles == nil {
+		res.Roles = Roles{}
+	}
+	return res
+}
+
+func NewUserResponses(users []*User) []*UserResponse {
+	res := make([]*UserResponse, len(users))
+	for i, user := range users {
+		res[i] = NewUserResponse(user)
+	}
+	return res
+}
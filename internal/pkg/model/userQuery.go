@@ -0,0 +1,217 @@
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+const (
+	DefaultUserLimit = 20
+	MaxUserLimit     = 100
+)
+
+// 정렬 가능한 field -> column
+var userSortColumns = map[string]string{
+	"id":        "id",
+	"name":      "name",
+	"age":       "age",
+	"birthday":  "birthday",
+	"createdAt": "created_at",
+}
+
+// 사용자 목록 조회 조건
+//
+// After 가 있으면 Page 대신 cursor 이후부터 조회
+type UserQuery struct {
+	Page  int
+	Limit int
+	After *UserCursor
+
+	Sort string
+	Desc bool
+
+	// 이름 부분 일치, 대소문자 구분 없음
+	Name       string
+	Status     UserStatus
+	MinAge     *int
+	MaxAge     *int
+	BornAfter  *time.Time
+	BornBefore *time.Time
+}
+
+type UserPage struct {
+	Items []*UserResponse `json:"items"`
+	Total int64           `json:"total"`
+	Page  int             `json:"page,omitempty"`
+	Limit int             `json:"limit"`
+	// 다음 페이지가 있을 때만 설정
+	NextCursor string `json:"nextCursor,omitempty"`
+	Next       string `json:"next,omitempty"`
+}
+
+// 마지막으로 조회한 행의 정렬 값과 id
+type UserCursor struct {
+	Sort  string          `json:"s"`
+	Desc  bool            `json:"d"`
+	Value json.RawMessage `json:"v"`
+	ID    uint            `json:"id"`
+}
+
+func ValidUserSort(sort string) bool {
+	_, ok := userSortColumns[sort]
+	return ok
+}
+
+func (c *UserCursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// 정렬 조건이 다른 cursor 는 사용할 수 없음
+func DecodeUserCursor(s, sort string, desc bool) (*UserCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	cursor := &UserCursor{}
+	if err := json.Unmarshal(b, cursor); err != nil || cursor.Sort != sort || cursor.Desc != desc {
+		return nil, ErrInvalidCursor
+	}
+	if _, err := cursor.value(); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return cursor, nil
+}
+
+func newUserCursor(user *User, sort string, desc bool) *UserCursor {
+	var value interface{}
+	switch sort {
+	case "name":
+		value = user.Name
+	case "age":
+		value = user.Age
+	case "birthday":
+		value = user.Birthday
+	case "createdAt":
+		value = user.CreatedAt
+	default:
+		value = user.ID
+	}
+	b, _ := json.Marshal(value)
+	return &UserCursor{Sort: sort, Desc: desc, Value: b, ID: user.ID}
+}
+
+func (c *UserCursor) value() (interface{}, error) {
+	switch c.Sort {
+	case "name":
+		var v string
+		err := json.Unmarshal(c.Value, &v)
+		return v, err
+	case "age":
+		var v int
+		err := json.Unmarshal(c.Value, &v)
+		return v, err
+	case "birthday", "createdAt":
+		var v time.Time
+		err := json.Unmarshal(c.Value, &v)
+		return v, err
+	default:
+		var v uint
+		err := json.Unmarshal(c.Value, &v)
+		return v, err
+	}
+}
+
+// LIKE 의 %, _ 는 문자 그대로 검색
+//
+// mysql 은 문자열의 \ 를 escape 로 처리하므로 모든 dialect 에서 같은 의미인 ! 사용
+func escapeLike(s string) string {
+	return strings.NewReplacer(`!`, `!!`, `%`, `!%`, `_`, `!_`).Replace(s)
+}
+
+// 등록된 모든 dialect 공통 목록 조회, dialect 별 분기 없이 같은 gorm query 사용
+func listUsers(db *gorm.DB, q *UserQuery) (*UserPage, error) {
+	sort := q.Sort
+	column, ok := userSortColumns[sort]
+	if !ok {
+		sort, column = "id", "id"
+	}
+	limit := q.Limit
+	if limit <= 0 {
+		limit = DefaultUserLimit
+	}
+	if limit > MaxUserLimit {
+		limit = MaxUserLimit
+	}
+
+	filtered := db.Model(&User{})
+	if q.Name != "" {
+		filtered = filtered.Where(`LOWER(name) LIKE ? ESCAPE '!'`, "%"+escapeLike(strings.ToLower(q.Name))+"%")
+	}
+	if q.Status != "" {
+		filtered = filtered.Where("status = ?", q.Status)
+	}
+	if q.MinAge != nil {
+		filtered = filtered.Where("age >= ?", *q.MinAge)
+	}
+	if q.MaxAge != nil {
+		filtered = filtered.Where("age <= ?", *q.MaxAge)
+	}
+	if q.BornAfter != nil {
+		filtered = filtered.Where("birthday >= ?", *q.BornAfter)
+	}
+	if q.BornBefore != nil {
+		filtered = filtered.Where("birthday < ?", *q.BornBefore)
+	}
+
+	page := &UserPage{Limit: limit}
+	if err := filtered.Session(&gorm.Session{}).Count(&page.Total).Error; err != nil {
+		return nil, err
+	}
+
+	direction, compare := "ASC", ">"
+	if q.Desc {
+		direction, compare = "DESC", "<"
+	}
+
+	// 같은 값은 id 순서로 정렬하여 cursor 위치 고정
+	query := filtered.Session(&gorm.Session{}).
+		Order(fmt.Sprintf("%s %s, id %s", column, direction, direction)).
+		Limit(limit + 1)
+
+	if q.After != nil {
+		value, _ := q.After.value()
+		query = query.Where(
+			fmt.Sprintf("((%s %s ?) OR (%s = ? AND id %s ?))", column, compare, column, compare),
+			value, value, q.After.ID,
+		)
+	} else {
+		p := q.Page
+		if p < 1 {
+			p = 1
+		}
+		page.Page = p
+		query = query.Offset((p - 1) * limit)
+	}
+
+	users := []*User{}
+	if err := query.Find(&users).Error; err != nil {
+		return nil, err
+	}
+
+	if len(users) > limit {
+		users = users[:limit]
+		page.NextCursor = newUserCursor(users[limit-1], sort, q.Desc).Encode()
+	}
+	page.Items = NewUserResponses(users)
+
+	return page, nil
+}
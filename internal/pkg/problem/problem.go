@@ -0,0 +1,51 @@
+package problem
+
+import (
+	"backend/internal/pkg/model"
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+const ContentType = "application/problem+json"
+
+// RFC 7807 error 응답 body
+type Problem struct {
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// status 와 상세 내용으로 problem 응답
+func JSON(c echo.Context, status int, detail string) error {
+	c.Response().Header().Set(echo.HeaderContentType, ContentType)
+	return c.JSON(status, &Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+	})
+}
+
+// DBHandler error 를 404/409/422 로, 제한 시간 초과는 504 로 변환, 그 외는 500
+//
+//	if err := u.db.DeleteUserById(id); err != nil {
+//		return problem.DBError(c, err)
+//	}
+func DBError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, model.ErrNotFound):
+		return JSON(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, model.ErrConflict):
+		return JSON(c, http.StatusConflict, err.Error())
+	case errors.Is(err, model.ErrValidation):
+		return JSON(c, http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return JSON(c, http.StatusGatewayTimeout, "database query timed out")
+	}
+	c.Logger().Error(err)
+	return JSON(c, http.StatusInternalServerError, "internal server error")
+}
@@ -0,0 +1,178 @@
+package security
+
+import (
+	"backend/config"
+	"backend/internal/pkg/model"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// api key 원문 형태 "ak_<prefix>_<secret>"
+const apiKeyPrefix = "ak_"
+
+var (
+	ErrInvalidAPIKey = errors.New("invalid api key")
+	ErrAPIKeyExpired = errors.New("api key expired")
+)
+
+const defaultAPIKeyTTL = 90 * 24 * time.Hour
+
+const (
+	// prefix random byte 수, hex 로 16 자
+	apiKeyPrefixSize = 8
+	// prefix 충돌 시 생성 시도 횟수
+	apiKeyCreateAttempts = 3
+)
+
+type CreateAPIKeyRequest struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+	// 미입력 시 apiKeyTTL 후 만료
+	ExpiresAt *time.Time `json:"expiresAt"`
+}
+
+// 생성 응답, key 원문은 이때만 확인 가능
+type CreateAPIKeyResponse struct {
+	*model.APIKey
+	Key string `json:"key"`
+}
+
+func IsAPIKey(auth string) bool {
+	return strings.HasPrefix(auth, apiKeyPrefix)
+}
+
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// 알 수 없는 권한이 있으면 error
+func ValidateScopes(scopes []string) error {
+	for _, scope := range scopes {
+		if !HasPermission([]string{RoleAdmin}, scope) {
+			return fmt.Errorf("unknown scope %q", scope)
+		}
+	}
+	return nil
+}
+
+type APIKeyService struct {
+	cfg config.Config
+	db  model.DBHandler
+}
+
+func NewAPIKeyService(cfg config.Config, db model.DBHandler) *APIKeyService {
+	return &APIKeyService{cfg: cfg, db: db}
+}
+
+func (a *APIKeyService) Create(ctx context.Context, userID uint, req *CreateAPIKeyRequest) (*CreateAPIKeyResponse, error) {
+	expiresAt := req.ExpiresAt
+	if expiresAt == nil {
+		ttl := a.cfg.APIKeyTTL
+		if ttl <= 0 {
+			ttl = defaultAPIKeyTTL
+		}
+		t := time.Now().Add(ttl)
+		expiresAt = &t
+	}
+
+	// prefix 는 unique index 라 충돌 시 다시 생성
+	for attempt := 1; ; attempt++ {
+		raw, key, err := newAPIKey(userID, req, expiresAt)
+		if err != nil {
+			return nil, err
+		}
+		err = a.db.AddAPIKey(ctx, key)
+		if errors.Is(err, model.ErrConflict) && attempt < apiKeyCreateAttempts {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &CreateAPIKeyResponse{APIKey: key, Key: raw}, nil
+	}
+}
+
+// 원문과 저장할 key 생성
+func newAPIKey(userID uint, req *CreateAPIKeyRequest, expiresAt *time.Time) (string, *model.APIKey, error) {
+	b := make([]byte, apiKeyPrefixSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", nil, err
+	}
+	prefix := hex.EncodeToString(b)
+
+	secret, err := randomToken(32)
+	if err != nil {
+		return "", nil, err
+	}
+	raw := apiKeyPrefix + prefix + "_" + secret
+
+	return raw, &model.APIKey{
+		UserID:    userID,
+		Name:      req.Name,
+		Prefix:    prefix,
+		KeyHash:   hashAPIKey(raw),
+		Scopes:    req.Scopes,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// api key 를 jwt 와 같은 claims 로 변환, role 은 요청 시점의 사용자 role 사용
+func (a *APIKeyService) Authenticate(ctx context.Context, raw string) (*jwt.Token, error) {
+	parts := strings.SplitN(strings.TrimPrefix(raw, apiKeyPrefix), "_", 2)
+	if len(parts) != 2 {
+		return nil, ErrInvalidAPIKey
+	}
+
+	key, err := a.db.GetAPIKeyByPrefix(ctx, parts[0])
+	if errors.Is(err, model.ErrNotFound) {
+		return nil, ErrInvalidAPIKey
+	}
+	if err != nil {
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(key.KeyHash), []byte(hashAPIKey(raw))) != 1 {
+		return nil, ErrInvalidAPIKey
+	}
+	if key.RevokedAt != nil {
+		return nil, ErrTokenRevoked
+	}
+	if key.ExpiresAt != nil && time.Now().After(*key.ExpiresAt) {
+		return nil, ErrAPIKeyExpired
+	}
+
+	user, err := a.db.GetUserById(ctx, int(key.UserID))
+	if errors.Is(err, model.ErrNotFound) {
+		return nil, ErrInvalidAPIKey
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !user.Active() {
+		return nil, ErrUserInactive
+	}
+
+	claims := &jwtCustomClaims{
+		ID:     user.ID,
+		Name:   user.Name,
+		Roles:  UserRoles(user),
+		Scopes: append([]string{}, key.Scopes...),
+		// 요청 제한 key
+		apiKeyPrefix: key.Prefix,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject: strconv.FormatUint(uint64(user.ID), 10),
+		},
+	}
+
+	return &jwt.Token{Claims: claims, Valid: true}, nil
+}
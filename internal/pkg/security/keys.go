@@ -0,0 +1,219 @@
+package security
+
+import (
+	"backend/config"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+var ErrUnknownKey = errors.New("unknown jwt key")
+
+// 지원하는 비대칭 서명 algorithm 과 ECDSA curve
+var keyCurves = map[string]elliptic.Curve{
+	"RS256": nil,
+	"RS384": nil,
+	"RS512": nil,
+	"ES256": elliptic.P256(),
+	"ES384": elliptic.P384(),
+	"ES512": elliptic.P521(),
+}
+
+type jwtKey struct {
+	id      string
+	method  jwt.SigningMethod
+	private crypto.PrivateKey
+	public  crypto.PublicKey
+}
+
+// token 서명 및 검증용 key 목록
+//
+// jwtKeys 가 없으면 jwtSigningKey 로 HS256 서명
+type KeySet struct {
+	secret []byte
+	active *jwtKey
+	keys   map[string]*jwtKey
+	order  []string
+}
+
+func NewKeySet(cfg config.Config) (*KeySet, error) {
+	if len(cfg.JWTKeys) == 0 {
+		return &KeySet{secret: []byte(cfg.JWTSigningKey)}, nil
+	}
+
+	k := &KeySet{keys: make(map[string]*jwtKey, len(cfg.JWTKeys))}
+	for _, c := range cfg.JWTKeys {
+		key, err := loadJWTKey(c)
+		if err != nil {
+			return nil, fmt.Errorf("jwt key %q: %w", c.ID, err)
+		}
+		if _, ok := k.keys[key.id]; ok {
+			return nil, fmt.Errorf("duplicated jwt key %q", key.id)
+		}
+		k.keys[key.id] = key
+		k.order = append(k.order, key.id)
+	}
+
+	// jwtActiveKey 미설정 시 private key 가 있는 첫번째 key 로 서명
+	for _, id := range k.order {
+		key := k.keys[id]
+		if key.private == nil {
+			continue
+		}
+		if cfg.JWTActiveKey == "" || cfg.JWTActiveKey == id {
+			k.active = key
+			break
+		}
+	}
+	if k.active == nil {
+		return nil, fmt.Errorf("jwt active key %q has no private key", cfg.JWTActiveKey)
+	}
+
+	return k, nil
+}
+
+func loadJWTKey(c config.JWTKey) (*jwtKey, error) {
+	if c.ID == "" {
+		return nil, errors.New("id is required")
+	}
+
+	alg := c.Algorithm
+	if alg == "" {
+		alg = "RS256"
+	}
+	curve, ok := keyCurves[alg]
+	if !ok {
+		return nil, fmt.Errorf("unsupported algorithm %q", alg)
+	}
+
+	key := &jwtKey{id: c.ID, method: jwt.GetSigningMethod(alg)}
+
+	if c.PrivateKeyPath != "" {
+		pem, err := os.ReadFile(c.PrivateKeyPath)
+		if err != nil {
+			return nil, err
+		}
+		if curve == nil {
+			private, err := jwt.ParseRSAPrivateKeyFromPEM(pem)
+			if err != nil {
+				return nil, err
+			}
+			key.private, key.public = private, &private.PublicKey
+		} else {
+			private, err := jwt.ParseECPrivateKeyFromPEM(pem)
+			if err != nil {
+				return nil, err
+			}
+			key.private, key.public = private, &private.PublicKey
+		}
+	}
+
+	if c.PublicKeyPath != "" {
+		pem, err := os.ReadFile(c.PublicKeyPath)
+		if err != nil {
+			return nil, err
+		}
+		if curve == nil {
+			key.public, err = jwt.ParseRSAPublicKeyFromPEM(pem)
+		} else {
+			key.public, err = jwt.ParseECPublicKeyFromPEM(pem)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if key.public == nil {
+		return nil, errors.New("privateKeyPath or publicKeyPath is required")
+	}
+	if public, ok := key.public.(*ecdsa.PublicKey); ok && public.Curve != curve {
+		return nil, fmt.Errorf("curve %s does not match %s", public.Curve.Params().Name, alg)
+	}
+
+	return key, nil
+}
+
+// 서명 후 kid header 추가
+func (k *KeySet) Sign(claims jwt.Claims) (string, error) {
+	if k.active == nil {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(k.secret)
+	}
+
+	token := jwt.NewWithClaims(k.active.method, claims)
+	token.Header["kid"] = k.active.id
+	return token.SignedString(k.active.private)
+}
+
+// kid 로 검증 key 선택, key 의 algorithm 과 다른 token 은 거부
+func (k *KeySet) Keyfunc(t *jwt.Token) (interface{}, error) {
+	if k.active == nil {
+		if t.Method.Alg() != jwt.SigningMethodHS256.Alg() {
+			return nil, fmt.Errorf("unexpected jwt signing method %v", t.Header["alg"])
+		}
+		return k.secret, nil
+	}
+
+	kid, _ := t.Header["kid"].(string)
+	key, ok := k.keys[kid]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	if t.Method.Alg() != key.method.Alg() {
+		return nil, fmt.Errorf("unexpected jwt signing method %v", t.Header["alg"])
+	}
+	return key.public, nil
+}
+
+// RFC 7517 JSON Web Key
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// ECDSA
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// 공개키 목록, HS256 사용 시 공개할 key 없음
+func (k *KeySet) JWKS() *JWKS {
+	jwks := &JWKS{Keys: []JWK{}}
+
+	for _, id := range k.order {
+		key := k.keys[id]
+		jwk := JWK{Kid: key.id, Use: "sig", Alg: key.method.Alg()}
+
+		switch public := key.public.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case *ecdsa.PublicKey:
+			size := (public.Curve.Params().BitSize + 7) / 8
+			jwk.Kty = "EC"
+			jwk.Crv = public.Curve.Params().Name
+			jwk.X = base64.RawURLEncoding.EncodeToString(public.X.FillBytes(make([]byte, size)))
+			jwk.Y = base64.RawURLEncoding.EncodeToString(public.Y.FillBytes(make([]byte, size)))
+		}
+
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+
+	return jwks
+}
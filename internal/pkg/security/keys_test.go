@@ -0,0 +1,130 @@
+package security
+
+import (
+	"backend/config"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func writePEM(t *testing.T, name, blockType string, der []byte) string {
+	path := filepath.Join(t.TempDir(), name)
+	err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestKeySetRotation(t *testing.T) {
+	assert := assert.New(t)
+
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	rsaPrivate := writePEM(t, "new.pem", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey))
+	ecPrivateDER, _ := x509.MarshalECPrivateKey(ecKey)
+	ecPrivate := writePEM(t, "old.pem", "EC PRIVATE KEY", ecPrivateDER)
+	ecPublicDER, _ := x509.MarshalPKIXPublicKey(&ecKey.PublicKey)
+	ecPublic := writePEM(t, "old.pub.pem", "PUBLIC KEY", ecPublicDER)
+
+	// 교체 전: ES256 key 로 서명
+	before := config.Config{
+		JWTKeys: []config.JWTKey{
+			{ID: "old", Algorithm: "ES256", PrivateKeyPath: ecPrivate},
+		},
+	}
+	// 교체 후: RS256 key 로 서명, 이전 key 는 공개키만 남겨 검증
+	after := config.Config{
+		JWTActiveKey: "new",
+		JWTKeys: []config.JWTKey{
+			{ID: "old", Algorithm: "ES256", PublicKeyPath: ecPublic},
+			{ID: "new", Algorithm: "RS256", PrivateKeyPath: rsaPrivate},
+		},
+	}
+
+	oldToken, err := JsonWebTokenIssuer(before, 1, "a")
+	assert.NoError(err)
+	newToken, err := JsonWebTokenIssuer(after, 1, "a")
+	assert.NoError(err)
+
+	keys, err := NewKeySet(after)
+	assert.NoError(err)
+
+	// kid header 로 검증 key 선택
+	for kid, token := range map[string]string{"old": oldToken, "new": newToken} {
+		claims := &jwtCustomClaims{}
+		parsed, err := jwt.ParseWithClaims(token, claims, keys.Keyfunc)
+		if assert.NoError(err, kid) {
+			assert.Equal(kid, parsed.Header["kid"])
+			assert.Equal("a", claims.Name)
+		}
+	}
+
+	// 모르는 kid, HS256 token 은 거부
+	unknown := jwt.NewWithClaims(jwt.SigningMethodRS256, &jwtCustomClaims{})
+	unknown.Header["kid"] = "unknown"
+	signed, _ := unknown.SignedString(rsaKey)
+	_, err = jwt.ParseWithClaims(signed, &jwtCustomClaims{}, keys.Keyfunc)
+	assert.ErrorIs(err, ErrUnknownKey)
+
+	hsToken, _ := JsonWebTokenIssuer(config.Config{JWTSigningKey: "jwtkey"}, 1, "a")
+	_, err = jwt.ParseWithClaims(hsToken, &jwtCustomClaims{}, keys.Keyfunc)
+	assert.Error(err)
+
+	// key 의 algorithm 과 다른 token 은 거부
+	mismatch := jwt.NewWithClaims(jwt.SigningMethodRS256, &jwtCustomClaims{})
+	mismatch.Header["kid"] = "old"
+	signed, _ = mismatch.SignedString(rsaKey)
+	_, err = jwt.ParseWithClaims(signed, &jwtCustomClaims{}, keys.Keyfunc)
+	assert.Error(err)
+
+	// jwks 에 두 공개키 모두 포함
+	jwks := keys.JWKS()
+	if assert.Equal(2, len(jwks.Keys)) {
+		ec, rs := jwks.Keys[0], jwks.Keys[1]
+
+		assert.Equal(JWK{Kty: "EC", Kid: "old", Use: "sig", Alg: "ES256", Crv: "P-256", X: ec.X, Y: ec.Y}, ec)
+		x, _ := base64.RawURLEncoding.DecodeString(ec.X)
+		assert.Equal(32, len(x))
+		assert.Equal(0, new(big.Int).SetBytes(x).Cmp(ecKey.X))
+
+		assert.Equal("RSA", rs.Kty)
+		assert.Equal("new", rs.Kid)
+		assert.Equal("RS256", rs.Alg)
+		n, _ := base64.RawURLEncoding.DecodeString(rs.N)
+		assert.Equal(0, new(big.Int).SetBytes(n).Cmp(rsaKey.N))
+		assert.Equal("AQAB", rs.E)
+	}
+}
+
+func TestNewKeySetErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	ecPublicDER, _ := x509.MarshalPKIXPublicKey(&ecKey.PublicKey)
+	ecPublic := writePEM(t, "p384.pub.pem", "PUBLIC KEY", ecPublicDER)
+
+	for name, keys := range map[string][]config.JWTKey{
+		"no id":           {{Algorithm: "RS256", PublicKeyPath: ecPublic}},
+		"unsupported alg": {{ID: "a", Algorithm: "HS256", PublicKeyPath: ecPublic}},
+		"no key file":     {{ID: "a", Algorithm: "ES256"}},
+		"missing file":    {{ID: "a", Algorithm: "ES256", PrivateKeyPath: "not-exists.pem"}},
+		"curve mismatch":  {{ID: "a", Algorithm: "ES256", PublicKeyPath: ecPublic}},
+		"no private key":  {{ID: "a", Algorithm: "ES384", PublicKeyPath: ecPublic}},
+	} {
+		_, err := NewKeySet(config.Config{JWTKeys: keys})
+		assert.Error(err, name)
+	}
+}
@@ -0,0 +1,384 @@
+package security
+
+import (
+	"backend/config"
+	"backend/internal/pkg/model"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"golang.org/x/oauth2"
+)
+
+var (
+	ErrInvalidOIDCState = errors.New("invalid or expired oidc state")
+	ErrInvalidIDToken   = errors.New("invalid id token")
+)
+
+// login 시작 후 callback 까지 허용 시간
+const oidcStateTTL = 10 * time.Minute
+
+// 모르는 kid 로 jwks 를 다시 조회하는 최소 간격
+const jwksRefreshInterval = time.Minute
+
+// id token 에서 읽은 사용자 정보
+type OIDCIdentity struct {
+	Issuer  string
+	Subject string
+	Name    string
+	Email   string
+	Groups  []string
+}
+
+// 외부 사용자 식별자, model.User.ExternalID 에 저장
+func (i *OIDCIdentity) ExternalID() string {
+	return i.Issuer + "|" + i.Subject
+}
+
+type oidcDiscovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type oidcState struct {
+	verifier  string
+	nonce     string
+	expiresAt time.Time
+}
+
+// authorization code + PKCE flow
+//
+// state 는 instance memory 에 저장하므로 login 시작과 callback 이 같은 instance 로 와야 함
+type OIDCProvider struct {
+	cfg    config.OIDC
+	client *http.Client
+
+	mu        sync.Mutex
+	discovery *oidcDiscovery
+	keys      map[string]crypto.PublicKey
+	// 마지막 jwks 조회 시각, 실패한 조회 포함
+	keysFetchedAt time.Time
+	states        map[string]*oidcState
+}
+
+func NewOIDCProvider(cfg config.OIDC, client *http.Client) *OIDCProvider {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	if cfg.NameClaim == "" {
+		cfg.NameClaim = "preferred_username"
+	}
+	if cfg.GroupsClaim == "" {
+		cfg.GroupsClaim = "groups"
+	}
+	return &OIDCProvider{
+		cfg:    cfg,
+		client: client,
+		keys:   make(map[string]crypto.PublicKey),
+		states: make(map[string]*oidcState),
+	}
+}
+
+// provider 설정은 처음 사용할 때 조회, 실패 시 다음 요청에서 재시도
+func (o *OIDCProvider) discover(ctx context.Context) (*oidcDiscovery, error) {
+	o.mu.Lock()
+	discovery := o.discovery
+	o.mu.Unlock()
+	if discovery != nil {
+		return discovery, nil
+	}
+
+	discovery = &oidcDiscovery{}
+	url := strings.TrimSuffix(o.cfg.Issuer, "/") + "/.well-known/openid-configuration"
+	if err := o.getJSON(ctx, url, discovery); err != nil {
+		return nil, fmt.Errorf("oidc discovery: %w", err)
+	}
+	if discovery.Issuer != o.cfg.Issuer {
+		return nil, fmt.Errorf("oidc discovery: issuer %q does not match %q", discovery.Issuer, o.cfg.Issuer)
+	}
+
+	o.mu.Lock()
+	o.discovery = discovery
+	o.mu.Unlock()
+
+	return discovery, nil
+}
+
+func (o *OIDCProvider) getJSON(ctx context.Context, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s returned %s", url, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+func (o *OIDCProvider) oauth2Config(discovery *oidcDiscovery) *oauth2.Config {
+	scopes := o.cfg.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{"openid", "profile", "email"}
+	}
+	return &oauth2.Config{
+		ClientID:     o.cfg.ClientID,
+		ClientSecret: o.cfg.ClientSecret,
+		RedirectURL:  o.cfg.RedirectURL,
+		Scopes:       scopes,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  discovery.AuthorizationEndpoint,
+			TokenURL: discovery.TokenEndpoint,
+		},
+	}
+}
+
+// state, nonce, code verifier 생성 후 provider login url 반환
+func (o *OIDCProvider) AuthCodeURL(ctx context.Context) (string, error) {
+	discovery, err := o.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	state, err := randomToken(16)
+	if err != nil {
+		return "", err
+	}
+	nonce, err := randomToken(16)
+	if err != nil {
+		return "", err
+	}
+	verifier, err := randomToken(32)
+	if err != nil {
+		return "", err
+	}
+
+	o.mu.Lock()
+	now := time.Now()
+	for key, s := range o.states {
+		if now.After(s.expiresAt) {
+			delete(o.states, key)
+		}
+	}
+	o.states[state] = &oidcState{verifier: verifier, nonce: nonce, expiresAt: now.Add(oidcStateTTL)}
+	o.mu.Unlock()
+
+	challenge := sha256.Sum256([]byte(verifier))
+	return o.oauth2Config(discovery).AuthCodeURL(state,
+		oauth2.SetAuthURLParam("nonce", nonce),
+		oauth2.SetAuthURLParam("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:])),
+		oauth2.SetAuthURLParam("code_challenge_method", "S256"),
+	), nil
+}
+
+// state 는 1회만 사용 가능
+func (o *OIDCProvider) takeState(state string) (*oidcState, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	s, ok := o.states[state]
+	if !ok {
+		return nil, false
+	}
+	delete(o.states, state)
+	if time.Now().After(s.expiresAt) {
+		return nil, false
+	}
+	return s, true
+}
+
+// callback 의 code 를 token 으로 교환 후 id token 검증
+func (o *OIDCProvider) Exchange(ctx context.Context, code, state string) (*OIDCIdentity, error) {
+	s, ok := o.takeState(state)
+	if !ok || code == "" {
+		return nil, ErrInvalidOIDCState
+	}
+
+	discovery, err := o.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, o.client)
+	token, err := o.oauth2Config(discovery).Exchange(ctx, code, oauth2.SetAuthURLParam("code_verifier", s.verifier))
+	if err != nil {
+		return nil, fmt.Errorf("oidc token exchange: %w", err)
+	}
+
+	rawIDToken, _ := token.Extra("id_token").(string)
+	if rawIDToken == "" {
+		return nil, fmt.Errorf("%w: token response has no id_token", ErrInvalidIDToken)
+	}
+
+	return o.verifyIDToken(ctx, discovery, rawIDToken, s.nonce)
+}
+
+func (o *OIDCProvider) verifyIDToken(ctx context.Context, discovery *oidcDiscovery, raw, nonce string) (*OIDCIdentity, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(raw, claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := keyCurves[t.Method.Alg()]; !ok {
+			return nil, fmt.Errorf("unexpected id token signing method %v", t.Header["alg"])
+		}
+		kid, _ := t.Header["kid"].(string)
+		return o.publicKey(ctx, discovery, kid)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+
+	switch {
+	case !claims.VerifyIssuer(o.cfg.Issuer, true):
+		return nil, fmt.Errorf("%w: unexpected issuer", ErrInvalidIDToken)
+	case !claims.VerifyAudience(o.cfg.ClientID, true):
+		return nil, fmt.Errorf("%w: unexpected audience", ErrInvalidIDToken)
+	case claims["nonce"] != nonce:
+		return nil, fmt.Errorf("%w: nonce does not match", ErrInvalidIDToken)
+	}
+
+	identity := &OIDCIdentity{Issuer: o.cfg.Issuer}
+	identity.Subject, _ = claims["sub"].(string)
+	identity.Name, _ = claims[o.cfg.NameClaim].(string)
+	identity.Email, _ = claims["email"].(string)
+	if identity.Subject == "" {
+		return nil, fmt.Errorf("%w: no subject", ErrInvalidIDToken)
+	}
+	if identity.Name == "" {
+		identity.Name = identity.Subject
+	}
+
+	if groups, ok := claims[o.cfg.GroupsClaim].([]interface{}); ok {
+		for _, group := range groups {
+			if g, ok := group.(string); ok {
+				identity.Groups = append(identity.Groups, g)
+			}
+		}
+	}
+
+	return identity, nil
+}
+
+// 모르는 kid 면 provider 의 key 교체로 보고 jwks 재조회
+//
+// 위조된 kid 로 매번 외부 요청이 발생하지 않도록 재조회는 jwksRefreshInterval 에 한 번만 허용
+func (o *OIDCProvider) publicKey(ctx context.Context, discovery *oidcDiscovery, kid string) (crypto.PublicKey, error) {
+	o.mu.Lock()
+	key, ok := o.keys[kid]
+	refresh := !ok && time.Since(o.keysFetchedAt) >= jwksRefreshInterval
+	if refresh {
+		o.keysFetchedAt = time.Now()
+	}
+	o.mu.Unlock()
+	if ok {
+		return key, nil
+	}
+	if !refresh {
+		return nil, ErrUnknownKey
+	}
+
+	jwks := &JWKS{}
+	if err := o.getJSON(ctx, discovery.JWKSURI, jwks); err != nil {
+		return nil, fmt.Errorf("oidc jwks: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		public, err := jwk.PublicKey()
+		if err != nil {
+			continue
+		}
+		keys[jwk.Kid] = public
+	}
+
+	o.mu.Lock()
+	o.keys = keys
+	o.mu.Unlock()
+
+	if key, ok = keys[kid]; !ok {
+		return nil, ErrUnknownKey
+	}
+	return key, nil
+}
+
+// group claim 을 role 로 변환, 매핑되는 group 이 없으면 viewer
+func (o *OIDCProvider) Roles(identity *OIDCIdentity) model.Roles {
+	var roles model.Roles
+	for _, group := range identity.Groups {
+		role, ok := o.cfg.RoleMapping[group]
+		if !ok || ValidateRoles([]string{role}) != nil || roles.Has(role) {
+			continue
+		}
+		roles = append(roles, role)
+	}
+	if len(roles) == 0 {
+		return model.Roles{RoleViewer}
+	}
+	return roles
+}
+
+var jwkCurves = map[string]elliptic.Curve{
+	"P-256": elliptic.P256(),
+	"P-384": elliptic.P384(),
+	"P-521": elliptic.P521(),
+}
+
+// RSA, EC 공개키만 지원
+func (j *JWK) PublicKey() (crypto.PublicKey, error) {
+	decode := func(s string) (*big.Int, error) {
+		b, err := base64.RawURLEncoding.DecodeString(s)
+		if err != nil {
+			return nil, err
+		}
+		return new(big.Int).SetBytes(b), nil
+	}
+
+	switch j.Kty {
+	case "RSA":
+		n, err := decode(j.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decode(j.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		curve, ok := jwkCurves[j.Crv]
+		if !ok {
+			return nil, fmt.Errorf("unsupported curve %q", j.Crv)
+		}
+		x, err := decode(j.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decode(j.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	}
+	return nil, fmt.Errorf("unsupported key type %q", j.Kty)
+}
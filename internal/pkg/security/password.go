@@ -0,0 +1,25 @@
+package security
+
+import (
+	"golang.org/x/crypto/bcrypt"
+)
+
+// 존재하지 않는 사용자도 같은 시간이 걸리도록 비교에 사용하는 hash
+var dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("dummy-password"), bcrypt.DefaultCost)
+
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// hash 가 비어있으면 dummy hash 와 비교 후 false 반환
+func CheckPassword(hash, password string) bool {
+	if hash == "" {
+		bcrypt.CompareHashAndPassword(dummyPasswordHash, []byte(password))
+		return false
+	}
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
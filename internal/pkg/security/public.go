@@ -0,0 +1,111 @@
+package security
+
+import (
+	"backend/config"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/labstack/echo/v4"
+)
+
+// 등록 시 public 으로 표시한 route, "METHOD /route/:path" 형태
+var publicRoutes = struct {
+	sync.RWMutex
+	routes map[string]bool
+}{routes: make(map[string]bool)}
+
+// route 를 인증 없이 호출 가능하도록 표시
+//
+//	security.Public(login.POST("", handler.login))
+func Public(route *echo.Route) *echo.Route {
+	publicRoutes.Lock()
+	defer publicRoutes.Unlock()
+
+	publicRoutes.routes[route.Method+" "+route.Path] = true
+	return route
+}
+
+func isPublicRoute(method, routePath string) bool {
+	publicRoutes.RLock()
+	defer publicRoutes.RUnlock()
+
+	return publicRoutes.routes[method+" "+routePath]
+}
+
+// config 의 publicRoutes 규칙
+type publicRule struct {
+	method   string
+	segments []string
+}
+
+func newPublicRules(routes []config.PublicRoute) ([]publicRule, error) {
+	rules := make([]publicRule, 0, len(routes))
+	for _, r := range routes {
+		method := strings.ToUpper(r.Method)
+		if method == "*" {
+			method = ""
+		}
+		if !strings.HasPrefix(r.Path, "/") {
+			return nil, fmt.Errorf("public route path %q must start with /", r.Path)
+		}
+
+		segments := strings.Split(strings.TrimPrefix(r.Path, "/"), "/")
+		for i, segment := range segments {
+			if segment == "**" && i != len(segments)-1 {
+				return nil, fmt.Errorf("public route path %q: ** is only allowed at the end", r.Path)
+			}
+			if _, err := path.Match(segment, ""); err != nil {
+				return nil, fmt.Errorf("public route path %q: %w", r.Path, err)
+			}
+		}
+
+		rules = append(rules, publicRule{method: method, segments: segments})
+	}
+	return rules, nil
+}
+
+// path 의 * 는 한 단계, 마지막 ** 는 하위 경로 전체
+func (p publicRule) match(method, requestPath string) bool {
+	if p.method != "" && p.method != method {
+		return false
+	}
+
+	segments := strings.Split(strings.TrimPrefix(requestPath, "/"), "/")
+	for i, pattern := range p.segments {
+		if pattern == "**" {
+			return true
+		}
+		if i >= len(segments) {
+			return false
+		}
+		if ok, _ := path.Match(pattern, segments[i]); !ok {
+			return false
+		}
+	}
+	return len(segments) == len(p.segments)
+}
+
+// 등록 시 표시한 route 또는 config 규칙에 맞는 요청만 인증 생략
+func newSkipper(rules []publicRule) func(c echo.Context) bool {
+	return func(c echo.Context) bool {
+		method := c.Request().Method
+		if isPublicRoute(method, c.Path()) {
+			return true
+		}
+
+		// "..", "//" 등이 포함된 경로는 규칙과 비교하지 않음
+		requestPath := c.Request().URL.Path
+		if clean := path.Clean(requestPath); clean != requestPath && clean+"/" != requestPath {
+			return false
+		}
+
+		for _, rule := range rules {
+			if rule.match(method, requestPath) {
+				return true
+			}
+		}
+		return false
+	}
+}
@@ -0,0 +1,49 @@
+package security
+
+import (
+	"backend/config"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublicRules(t *testing.T) {
+	assert := assert.New(t)
+
+	rules, err := newPublicRules([]config.PublicRoute{
+		{Method: "get", Path: "/swagger/**"},
+		{Method: "GET", Path: "/favicon.ico"},
+		{Method: "*", Path: "/api/v1/health/*"},
+	})
+	assert.NoError(err)
+
+	matched := func(method, path string) bool {
+		for _, rule := range rules {
+			if rule.match(method, path) {
+				return true
+			}
+		}
+		return false
+	}
+
+	assert.True(matched("GET", "/swagger/index.html"))
+	assert.True(matched("GET", "/swagger/a/b"))
+	assert.True(matched("GET", "/swagger/"))
+	assert.True(matched("GET", "/favicon.ico"))
+	assert.True(matched("POST", "/api/v1/health/live"))
+
+	assert.False(matched("POST", "/swagger/index.html"))
+	assert.False(matched("GET", "/swaggerx"))
+	assert.False(matched("GET", "/favicon.ico/x"))
+	assert.False(matched("GET", "/api/v1/health"))
+	assert.False(matched("GET", "/api/v1/health/live/x"))
+
+	for _, route := range []config.PublicRoute{
+		{Path: "swagger"},
+		{Path: "/a/**/b"},
+		{Path: "/a/["},
+	} {
+		_, err := newPublicRules([]config.PublicRoute{route})
+		assert.Error(err, route.Path)
+	}
+}
@@ -0,0 +1,205 @@
+package security
+
+import (
+	"backend/config"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"golang.org/x/time/rate"
+)
+
+// 사용하지 않는 limiter 정리 주기
+const rateLimitIdleTTL = 10 * time.Minute
+
+// 테스트에서 시간 변경
+var timeNow = time.Now
+
+type limiterEntry struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// key(사용자, api key, ip) 별 token bucket
+type rateLimiter struct {
+	rule config.RateLimitRule
+
+	mu        sync.Mutex
+	limiters  map[string]*limiterEntry
+	lastSweep time.Time
+}
+
+func newRateLimiter(rule config.RateLimitRule) *rateLimiter {
+	return &rateLimiter{rule: rule, limiters: make(map[string]*limiterEntry)}
+}
+
+// 허용되지 않으면 다시 요청 가능할 때까지의 시간 반환
+func (r *rateLimiter) allow(key string) (bool, time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t := timeNow()
+	if t.Sub(r.lastSweep) > rateLimitIdleTTL {
+		for k, e := range r.limiters {
+			if t.Sub(e.lastSeen) > rateLimitIdleTTL {
+				delete(r.limiters, k)
+			}
+		}
+		r.lastSweep = t
+	}
+
+	e, ok := r.limiters[key]
+	if !ok {
+		e = &limiterEntry{limiter: rate.NewLimiter(rate.Limit(r.rule.Rate), r.rule.Burst)}
+		r.limiters[key] = e
+	}
+	e.lastSeen = t
+
+	reservation := e.limiter.ReserveN(t, 1)
+	if !reservation.OK() {
+		return false, time.Second
+	}
+	if delay := reservation.DelayFrom(t); delay > 0 {
+		reservation.CancelAt(t)
+		return false, delay
+	}
+	return true, 0
+}
+
+// 인증된 요청은 api key 또는 jwt subject, 아니면 client ip 기준
+func rateLimitKey(c echo.Context) string {
+	if claims := claimsFrom(c); claims != nil {
+		if claims.apiKeyPrefix != "" {
+			return "apikey:" + claims.apiKeyPrefix
+		}
+		return "sub:" + claims.Subject
+	}
+	return "ip:" + c.RealIP()
+}
+
+// 429 응답, Retry-After 는 초 단위 올림
+func TooManyRequests(c echo.Context, retryAfter time.Duration) error {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	c.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(seconds))
+	return echo.NewHTTPError(http.StatusTooManyRequests, "too many requests")
+}
+
+// route group 별 요청 제한
+//
+//	echo.Group("/api/v1/github", security.RateLimit(cfg, "github"))
+func RateLimit(cfg config.Config, group string) echo.MiddlewareFunc {
+	rule, ok := cfg.RateLimit.Groups[group]
+	if !ok {
+		rule = cfg.RateLimit.Default
+	}
+
+	if !cfg.RateLimit.Enabled || rule.Rate <= 0 {
+		return func(next echo.HandlerFunc) echo.HandlerFunc { return next }
+	}
+	if rule.Burst < 1 {
+		rule.Burst = 1
+	}
+
+	limiter := newRateLimiter(rule)
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if ok, retryAfter := limiter.allow(rateLimitKey(c)); !ok {
+				return TooManyRequests(c, retryAfter)
+			}
+			return next(c)
+		}
+	}
+}
+
+type loginFailure struct {
+	count       int
+	lastFailure time.Time
+	lockedUntil time.Time
+}
+
+// 연속 login 실패 시 계정 임시 잠금
+//
+// 없는 사용자도 같은 방식으로 잠가 사용자 존재 여부를 노출하지 않음,
+// 실패 기록은 instance memory 에 저장
+type LoginGuard struct {
+	maxFailures int
+	lockout     time.Duration
+
+	mu       sync.Mutex
+	failures map[string]*loginFailure
+}
+
+func NewLoginGuard(cfg config.RateLimit) *LoginGuard {
+	return &LoginGuard{
+		maxFailures: cfg.LoginMaxFailures,
+		lockout:     cfg.LoginLockout,
+		failures:    make(map[string]*loginFailure),
+	}
+}
+
+func (l *LoginGuard) enabled() bool {
+	return l.maxFailures > 0 && l.lockout > 0
+}
+
+// 잠긴 경우 남은 시간 반환
+func (l *LoginGuard) Locked(name string) (bool, time.Duration) {
+	if !l.enabled() {
+		return false, 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	f, ok := l.failures[strings.ToLower(name)]
+	if !ok {
+		return false, 0
+	}
+	if remaining := f.lockedUntil.Sub(timeNow()); remaining > 0 {
+		return true, remaining
+	}
+	return false, 0
+}
+
+// 잠금 시간 동안 추가 실패가 없으면 실패 횟수 초기화
+func (l *LoginGuard) Fail(name string) {
+	if !l.enabled() {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	t := timeNow()
+	for k, f := range l.failures {
+		if t.Sub(f.lastFailure) > l.lockout && t.After(f.lockedUntil) {
+			delete(l.failures, k)
+		}
+	}
+
+	key := strings.ToLower(name)
+	f, ok := l.failures[key]
+	if !ok {
+		f = &loginFailure{}
+		l.failures[key] = f
+	}
+	f.count++
+	f.lastFailure = t
+	if f.count >= l.maxFailures {
+		f.count = 0
+		f.lockedUntil = t.Add(l.lockout)
+	}
+}
+
+func (l *LoginGuard) Reset(name string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.failures, strings.ToLower(name))
+}
@@ -0,0 +1,131 @@
+package security
+
+import (
+	"backend/config"
+	"backend/internal/pkg/model"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// 테스트 동안 시간 고정
+func fixTime(t *testing.T) *time.Time {
+	current := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return current }
+	t.Cleanup(func() { timeNow = time.Now })
+	return &current
+}
+
+func TestRateLimit(t *testing.T) {
+	assert := assert.New(t)
+	current := fixTime(t)
+
+	e := echo.New()
+	cfg := config.Config{
+		JWTSigningKey: "jwtkey",
+		SqliteDBPath:  t.TempDir() + "/gorm.db",
+		RateLimit: config.RateLimit{
+			Enabled: true,
+			Default: config.RateLimitRule{Rate: 10, Burst: 10},
+			Groups: map[string]config.RateLimitRule{
+				"github": {Rate: 0.5, Burst: 2},
+			},
+		},
+		PublicRoutes: []config.PublicRoute{{Method: "GET", Path: "/public"}},
+	}
+	db, err := model.NewDBHandler(cfg)
+	assert.NoError(err)
+	assert.NoError(WebSecurityConfig(e, cfg, db))
+
+	ok := func(c echo.Context) error { return c.NoContent(http.StatusOK) }
+	github := e.Group("/api/v1/github", RateLimit(cfg, "github"))
+	github.GET("", ok)
+	e.GET("/public", ok, RateLimit(cfg, "github"))
+	e.GET("/api/v1/user", ok, RateLimit(cfg, "user"))
+
+	call := func(path, token, ip string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.RemoteAddr = ip + ":1234"
+		if token != "" {
+			req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	a, _ := JsonWebTokenIssuer(cfg, 1, "a", RoleViewer)
+	b, _ := JsonWebTokenIssuer(cfg, 2, "b", RoleViewer)
+
+	// 1. burst 까지 허용 후 429, Retry-After 는 다음 token 충전까지 (0.5/s -> 2s)
+	assert.Equal(http.StatusOK, call("/api/v1/github", a, "10.0.0.1").Code)
+	assert.Equal(http.StatusOK, call("/api/v1/github", a, "10.0.0.1").Code)
+
+	rec := call("/api/v1/github", a, "10.0.0.1")
+	assert.Equal(http.StatusTooManyRequests, rec.Code)
+	assert.Equal("2", rec.Header().Get(echo.HeaderRetryAfter))
+
+	// 같은 사용자는 ip 가 달라도 제한, 다른 사용자는 별도
+	assert.Equal(http.StatusTooManyRequests, call("/api/v1/github", a, "10.0.0.2").Code)
+	assert.Equal(http.StatusOK, call("/api/v1/github", b, "10.0.0.1").Code)
+
+	// group 별 limiter 는 별도, 설정이 없는 group 은 default 사용
+	assert.Equal(http.StatusOK, call("/api/v1/user", a, "10.0.0.1").Code)
+
+	// 2. 시간이 지나면 충전
+	*current = current.Add(2 * time.Second)
+	assert.Equal(http.StatusOK, call("/api/v1/github", a, "10.0.0.1").Code)
+	assert.Equal(http.StatusTooManyRequests, call("/api/v1/github", a, "10.0.0.1").Code)
+
+	// 3. 인증 없는 요청은 ip 기준
+	assert.Equal(http.StatusOK, call("/public", "", "10.0.0.3").Code)
+	assert.Equal(http.StatusOK, call("/public", "", "10.0.0.3").Code)
+	assert.Equal(http.StatusTooManyRequests, call("/public", "", "10.0.0.3").Code)
+	assert.Equal(http.StatusOK, call("/public", "", "10.0.0.4").Code)
+
+	// 4. 비활성화 시 제한 없음
+	cfg.RateLimit.Enabled = false
+	e.GET("/unlimited", ok, RateLimit(cfg, "github"))
+	for i := 0; i < 5; i++ {
+		assert.Equal(http.StatusOK, call("/unlimited", a, "10.0.0.1").Code)
+	}
+}
+
+func TestLoginGuard(t *testing.T) {
+	assert := assert.New(t)
+	current := fixTime(t)
+
+	guard := NewLoginGuard(config.RateLimit{LoginMaxFailures: 3, LoginLockout: time.Minute})
+
+	guard.Fail("admin")
+	guard.Fail("ADMIN")
+	locked, _ := guard.Locked("admin")
+	assert.False(locked)
+
+	// 대소문자 구분 없이 3회 실패 시 잠금
+	guard.Fail("Admin")
+	locked, retryAfter := guard.Locked("admin")
+	assert.True(locked)
+	assert.Equal(time.Minute, retryAfter)
+
+	// 다른 계정은 영향 없음
+	locked, _ = guard.Locked("other")
+	assert.False(locked)
+
+	// 잠금 시간 이후 해제
+	*current = current.Add(time.Minute)
+	locked, _ = guard.Locked("admin")
+	assert.False(locked)
+
+	// 성공 시 실패 횟수 초기화
+	guard.Fail("admin")
+	guard.Fail("admin")
+	guard.Reset("admin")
+	guard.Fail("admin")
+	locked, _ = guard.Locked("admin")
+	assert.False(locked)
+}
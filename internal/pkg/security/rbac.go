@@ -0,0 +1,129 @@
+package security
+
+import (
+	"backend/internal/pkg/model"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	RoleAdmin     = "admin"
+	RoleDeveloper = "developer"
+	RoleViewer    = "viewer"
+)
+
+const (
+	PermRepoRead         = "repo:read"
+	PermRepoWrite        = "repo:write"
+	PermRepoDelete       = "repo:delete"
+	PermIssueWrite       = "issue:write"
+	PermWorkflowDispatch = "workflow:dispatch"
+	PermUserRead         = "user:read"
+	PermUserWrite        = "user:write"
+	PermK8sRead          = "k8s:read"
+	PermK8sWrite         = "k8s:write"
+)
+
+var viewerPermissions = []string{
+	PermRepoRead,
+	PermUserRead,
+	PermK8sRead,
+}
+
+var developerPermissions = append([]string{
+	PermRepoWrite,
+	PermIssueWrite,
+	PermWorkflowDispatch,
+	PermK8sWrite,
+}, viewerPermissions...)
+
+var adminPermissions = append([]string{
+	PermRepoDelete,
+	PermUserWrite,
+}, developerPermissions...)
+
+var rolePermissions = map[string][]string{
+	RoleAdmin:     adminPermissions,
+	RoleDeveloper: developerPermissions,
+	RoleViewer:    viewerPermissions,
+}
+
+// 권한 부족 시 응답 body
+type ForbiddenResponse struct {
+	Message    string `json:"message"`
+	Permission string `json:"permission"`
+}
+
+// 알 수 없는 role 이 있으면 error
+func ValidateRoles(roles []string) error {
+	for _, role := range roles {
+		if _, ok := rolePermissions[role]; !ok {
+			return fmt.Errorf("unknown role %q", role)
+		}
+	}
+	return nil
+}
+
+// role 이 없는 사용자는 viewer
+func UserRoles(user *model.User) []string {
+	if len(user.Roles) == 0 {
+		return []string{RoleViewer}
+	}
+	return user.Roles
+}
+
+func HasPermission(roles []string, permission string) bool {
+	for _, role := range roles {
+		for _, p := range rolePermissions[role] {
+			if p == permission {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// role 의 권한이 있고, api key 인 경우 scope 에도 포함되어야 함
+func (j *jwtCustomClaims) can(permission string) bool {
+	if !HasPermission(j.Roles, permission) {
+		return false
+	}
+	if j.Scopes == nil {
+		return true
+	}
+	for _, scope := range j.Scopes {
+		if scope == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// 요청자가 권한을 가지고 있는지 확인
+func Can(c echo.Context, permission string) bool {
+	claims := claimsFrom(c)
+	return claims != nil && claims.can(permission)
+}
+
+// route 별 필요 권한 선언
+//
+//	group.DELETE("/:owner/:repo", handler.deleteRepo, security.RequirePermission(security.PermRepoDelete))
+func RequirePermission(permission string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			claims := claimsFrom(c)
+			if claims == nil {
+				return echo.ErrUnauthorized
+			}
+			if !claims.can(permission) {
+				return c.JSON(http.StatusForbidden, &ForbiddenResponse{
+					Message:    "forbidden",
+					Permission: permission,
+				})
+			}
+			return next(c)
+		}
+	}
+}
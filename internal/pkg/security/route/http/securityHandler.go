@@ -2,38 +2,481 @@ package http
 
 import (
 	"backend/config"
+	"backend/internal/pkg/model"
+	"backend/internal/pkg/problem"
 	"backend/internal/pkg/security"
+	"context"
+	"errors"
+	"fmt"
+	"log"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 )
 
 type SecurityHandler struct {
-	cfg config.Config
+	cfg     config.Config
+	db      model.DBHandler
+	keys    *security.KeySet
+	tokens  *security.TokenService
+	apiKeys *security.APIKeyService
+	guard   *security.LoginGuard
+	// oidc 미사용 시 nil
+	oidc *security.OIDCProvider
 }
 
-func NewSecurityHandler(echo *echo.Echo, cfg config.Config) *SecurityHandler {
+// 같은 이름 또는 email 의 로컬 사용자가 이미 있음
+var errOIDCUserConflict = errors.New("user name or email already used by another account")
 
-	handler := &SecurityHandler{cfg}
+func NewSecurityHandler(echo *echo.Echo, cfg config.Config, db model.DBHandler) (*SecurityHandler, error) {
 
-	security := echo.Group("/api/v1/login")
+	keys, err := security.NewKeySet(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	handler := &SecurityHandler{
+		cfg:     cfg,
+		db:      db,
+		keys:    keys,
+		tokens:  security.NewTokenService(cfg, db, keys),
+		apiKeys: security.NewAPIKeyService(cfg, db),
+		guard:   security.NewLoginGuard(cfg.RateLimit),
+	}
+
+	if err := handler.createAdmin(context.Background()); err != nil {
+		return nil, err
+	}
+
+	login := echo.Group("/api/v1/login", security.RateLimit(cfg, "login"))
 	{
-		security.GET("", handler.getAccessToken)
+		security.Public(login.POST("", handler.login))
+	}
+
+	if cfg.OIDC.Enabled {
+		handler.oidc = security.NewOIDCProvider(cfg.OIDC, nil)
+		security.Public(login.GET("/oidc", handler.oidcLogin))
+		security.Public(login.GET("/oidc/callback", handler.oidcCallback))
+	}
+
+	echo.POST("/api/v1/logout", handler.logout)
+
+	token := echo.Group("/api/v1/token", security.RateLimit(cfg, "login"))
+	{
+		security.Public(token.POST("/refresh", handler.refresh))
+		token.POST("/revoke", handler.revoke)
+	}
+
+	apiKeys := echo.Group("/api/v1/apikeys", security.RateLimit(cfg, "apikeys"))
+	{
+		apiKeys.POST("", handler.createAPIKey)
+		apiKeys.GET("", handler.getAPIKeys)
+		apiKeys.DELETE("/:id", handler.revokeAPIKey)
+	}
+
+	security.Public(echo.GET("/.well-known/jwks.json", handler.jwks))
+
+	return handler, nil
+}
+
+// 알려진 기본 password, admin 계정 생성 시 거부
+var defaultAdminPasswords = map[string]bool{"admin": true, "password": true, "changeme": true}
+
+// 설정된 admin 계정이 없으면 admin role 로 한 번만 생성
+//
+// 이미 있는 계정은 role 을 변경하지 않음, password 가 비어있거나 기본값이면 시작하지 않음
+func (s *SecurityHandler) createAdmin(ctx context.Context) error {
+	name, password := s.cfg.AdminName, s.cfg.AdminPassword
+	if name == "" && password == "" {
+		return nil
+	}
+	if name == "" {
+		return errors.New("adminPassword is set without adminName")
+	}
+	if password == "" || password == name || defaultAdminPasswords[strings.ToLower(password)] {
+		return fmt.Errorf("adminPassword for %q must be set to a non-default value", name)
+	}
+
+	_, err := s.db.GetUserByName(ctx, name)
+	switch {
+	case err == nil:
+		return nil
+	case !errors.Is(err, model.ErrNotFound):
+		return fmt.Errorf("admin user lookup: %w", err)
+	}
+
+	hash, err := security.HashPassword(password)
+	if err != nil {
+		return fmt.Errorf("admin password hash: %w", err)
+	}
+
+	if err := s.db.AddUser(ctx, &model.User{Name: name, PasswordHash: hash, Roles: model.Roles{security.RoleAdmin}}); err != nil {
+		return fmt.Errorf("admin user %q was not created: %w", name, err)
+	}
+	log.Printf("admin user %q created", name)
+
+	return nil
+}
+
+// @Summary		login (issue token)
+// @Description	check name and password and issue access token and refresh token
+// @name		login
+// @Accept		json
+// @Produce		json
+// @Param		login	body		security.LoginRequest	true	"name and password"
+// @Success		200		{object}	security.Token
+// @Failure		401		{object}	string
+// @Failure		403		{object}	string
+// @Failure		429		{object}	string
+// @Router		/api/v1/login [post]
+func (s *SecurityHandler) login(c echo.Context) error {
+
+	req := new(security.LoginRequest)
+
+	if err := c.Bind(req); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	// 연속 실패로 잠긴 계정은 password 확인 없이 거부
+	if locked, retryAfter := s.guard.Locked(req.Name); locked {
+		return security.TooManyRequests(c, retryAfter)
+	}
+
+	// 사용자가 없는 경우와 password 가 틀린 경우를 구분하지 않음
+	var passwordHash string
+	user, err := s.db.GetUserByName(c.Request().Context(), req.Name)
+	switch {
+	case err == nil:
+		passwordHash = user.PasswordHash
+	case !errors.Is(err, model.ErrNotFound):
+		return problem.DBError(c, err)
+	}
+
+	if req.Name == "" || !security.CheckPassword(passwordHash, req.Password) {
+		s.guard.Fail(req.Name)
+		return c.JSON(http.StatusUnauthorized, "invalid name or password")
+	}
+	s.guard.Reset(req.Name)
+
+	// 정지, 잠긴 계정은 password 가 맞아도 거부
+	if !user.Active() {
+		return c.JSON(http.StatusForbidden, fmt.Sprintf("user is %s", user.Status))
+	}
+
+	if err := s.db.UpdateLastLogin(c.Request().Context(), user.ID); err != nil {
+		return problem.DBError(c, err)
+	}
+
+	token, err := s.tokens.Issue(c.Request().Context(), user)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, token)
+}
+
+// @Summary		oidc login
+// @Description	redirect to identity provider (authorization code + PKCE)
+// @name		oidcLogin
+// @Success		302
+// @Failure		502	{object}	string
+// @Router		/api/v1/login/oidc [get]
+func (s *SecurityHandler) oidcLogin(c echo.Context) error {
+
+	url, err := s.oidc.AuthCodeURL(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusBadGateway, err.Error())
+	}
+
+	return c.Redirect(http.StatusFound, url)
+}
+
+// @Summary		oidc callback
+// @Description	exchange code, create or update user from id token and issue access token and refresh token
+// @name		oidcCallback
+// @Produce		json
+// @Param		code	query		string	true	"authorization code"
+// @Param		state	query		string	true	"state"
+// @Success		200		{object}	security.Token
+// @Failure		401		{object}	string
+// @Failure		403		{object}	string
+// @Failure		409		{object}	string
+// @Failure		502		{object}	string
+// @Router		/api/v1/login/oidc/callback [get]
+func (s *SecurityHandler) oidcCallback(c echo.Context) error {
+
+	// 사용자가 idp 에서 거부한 경우 등
+	if idpError := c.QueryParam("error"); idpError != "" {
+		return c.JSON(http.StatusUnauthorized, idpError)
+	}
+
+	identity, err := s.oidc.Exchange(c.Request().Context(), c.QueryParam("code"), c.QueryParam("state"))
+	switch {
+	case errors.Is(err, security.ErrInvalidOIDCState), errors.Is(err, security.ErrInvalidIDToken):
+		return c.JSON(http.StatusUnauthorized, err.Error())
+	case err != nil:
+		return c.JSON(http.StatusBadGateway, err.Error())
+	}
+
+	user, err := s.upsertOIDCUser(c.Request().Context(), identity)
+	switch {
+	case errors.Is(err, errOIDCUserConflict):
+		return c.JSON(http.StatusConflict, err.Error())
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	if !user.Active() {
+		return c.JSON(http.StatusForbidden, fmt.Sprintf("user is %s", user.Status))
+	}
+
+	if err := s.db.UpdateLastLogin(c.Request().Context(), user.ID); err != nil {
+		return problem.DBError(c, err)
 	}
 
-	return handler
+	token, err := s.tokens.Issue(c.Request().Context(), user)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, token)
 }
 
-//	@Summary		login (issue token)
-//	@Description	get access token
-//	@name			getAccessToken
-//	@Accept			json
-//	@Produce		json
-//	@Success		200	{object}	string
-//	@Router			/api/v1/login [get]
-func (s *SecurityHandler) getAccessToken(c echo.Context) error {
+// idp 사용자는 ExternalID 로 찾고, login 마다 이름, email, role 을 idp 기준으로 갱신
+//
+// idp 가 email 을 주지 않으면 저장된 email 유지
+func (s *SecurityHandler) upsertOIDCUser(ctx context.Context, identity *security.OIDCIdentity) (*model.User, error) {
+	roles := s.oidc.Roles(identity)
+
+	user, err := s.db.GetUserByExternalID(ctx, identity.ExternalID())
+	if errors.Is(err, model.ErrNotFound) {
+		user = &model.User{Name: identity.Name, Email: identity.Email, ExternalID: identity.ExternalID(), Roles: roles}
+		err = s.db.AddUser(ctx, user)
+	} else if err == nil {
+		update := &model.User{Name: identity.Name, Email: identity.Email, Roles: roles}
+		err = s.db.UpdateUserById(ctx, int(user.ID), update)
+		user.Name, user.Roles = update.Name, update.Roles
+		if update.Email != "" {
+			user.Email = update.Email
+		}
+	}
+
+	// 같은 이름, email 의 다른 사용자가 있으면 ErrConflict
+	if errors.Is(err, model.ErrConflict) {
+		return nil, errOIDCUserConflict
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// @Summary		refresh token
+// @Description	rotate refresh token and issue new access token, reused refresh token revokes the whole session
+// @name		refresh
+// @Accept		json
+// @Produce		json
+// @Param		refresh	body		security.RefreshRequest	true	"refresh token"
+// @Success		200		{object}	security.Token
+// @Failure		401		{object}	string
+// @Failure		403		{object}	string
+// @Router		/api/v1/token/refresh [post]
+func (s *SecurityHandler) refresh(c echo.Context) error {
+
+	req := new(security.RefreshRequest)
+
+	if err := c.Bind(req); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	token, err := s.tokens.Refresh(c.Request().Context(), req.RefreshToken)
+	switch {
+	case errors.Is(err, security.ErrInvalidRefreshToken), errors.Is(err, security.ErrRefreshTokenReused):
+		return c.JSON(http.StatusUnauthorized, err.Error())
+	case errors.Is(err, security.ErrUserInactive):
+		return c.JSON(http.StatusForbidden, err.Error())
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, token)
+}
+
+// @Summary		logout
+// @Description	revoke current access token and session of refresh token
+// @name		logout
+// @Accept		json
+// @Produce		json
+// @Param		refresh	body		security.RefreshRequest	false	"refresh token"
+// @Success		204
+// @Failure		401		{object}	string
+// @Router		/api/v1/logout [post]
+// @Security	ApiKeyAuth
+func (s *SecurityHandler) logout(c echo.Context) error {
+
+	req := new(security.RefreshRequest)
+
+	if err := c.Bind(req); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	err := s.tokens.Logout(c, req.RefreshToken)
+	switch {
+	case errors.Is(err, security.ErrInvalidRefreshToken):
+		return c.JSON(http.StatusUnauthorized, err.Error())
+	case err != nil:
+		return problem.DBError(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// @Summary		revoke all tokens
+// @Description	revoke current access token and all refresh tokens of user
+// @name		revoke
+// @Produce		json
+// @Success		204
+// @Failure		401		{object}	string
+// @Router		/api/v1/token/revoke [post]
+// @Security	ApiKeyAuth
+func (s *SecurityHandler) revoke(c echo.Context) error {
+
+	err := s.tokens.RevokeAll(c)
+	switch {
+	case errors.Is(err, security.ErrInvalidRefreshToken):
+		return c.JSON(http.StatusUnauthorized, err.Error())
+	case err != nil:
+		return problem.DBError(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// @Summary		create api key
+// @Description	create api key with scopes (permissions) for machine clients, key is returned only once
+// @name		createAPIKey
+// @Accept		json
+// @Produce		json
+// @Param		apiKey	body		security.CreateAPIKeyRequest	true	"name, scopes and expiry"
+// @Success		201		{object}	security.CreateAPIKeyResponse
+// @Failure		400		{object}	string
+// @Failure		403		{object}	security.ForbiddenResponse
+// @Router		/api/v1/apikeys [post]
+// @Security	ApiKeyAuth
+func (s *SecurityHandler) createAPIKey(c echo.Context) error {
+
+	userID, ok := security.UserID(c)
+	if !ok {
+		return echo.ErrUnauthorized
+	}
 
-	token := security.JsonWebTokenIssuer(s.cfg)
+	// api key 로 새 api key 를 만들 수 없음
+	if security.AuthenticatedByAPIKey(c) {
+		return c.JSON(http.StatusForbidden, &security.ForbiddenResponse{Message: "api key can not create api key"})
+	}
+
+	req := new(security.CreateAPIKeyRequest)
+
+	if err := c.Bind(req); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	if req.Name == "" {
+		return c.JSON(http.StatusBadRequest, "name is required")
+	}
+	if err := security.ValidateScopes(req.Scopes); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	if req.ExpiresAt != nil && req.ExpiresAt.Before(time.Now()) {
+		return c.JSON(http.StatusBadRequest, "expiresAt must be in the future")
+	}
+
+	// 본인이 가진 권한만 위임 가능
+	for _, scope := range req.Scopes {
+		if !security.Can(c, scope) {
+			return c.JSON(http.StatusForbidden, &security.ForbiddenResponse{Message: "forbidden", Permission: scope})
+		}
+	}
+
+	key, err := s.apiKeys.Create(c.Request().Context(), userID, req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusCreated, key)
+}
+
+// @Summary		get api keys
+// @Description	get api keys of current user
+// @name		getAPIKeys
+// @Produce		json
+// @Success		200	{array}	model.APIKey
+// @Router		/api/v1/apikeys [get]
+// @Security	ApiKeyAuth
+func (s *SecurityHandler) getAPIKeys(c echo.Context) error {
+
+	userID, ok := security.UserID(c)
+	if !ok {
+		return echo.ErrUnauthorized
+	}
+
+	keys, err := s.db.GetAPIKeysByUserId(c.Request().Context(), userID)
+	if err != nil {
+		return problem.DBError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, keys)
+}
+
+// @Summary		revoke api key
+// @Description	revoke api key of current user
+// @name		revokeAPIKey
+// @Param		id	path	string	true	"id of the api key"
+// @Success		204
+// @Failure		404	{object}	problem.Problem
+// @Router		/api/v1/apikeys/{id} [delete]
+// @Security	ApiKeyAuth
+func (s *SecurityHandler) revokeAPIKey(c echo.Context) error {
+
+	userID, ok := security.UserID(c)
+	if !ok {
+		return echo.ErrUnauthorized
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, c.Param("id"))
+	}
+
+	keys, err := s.db.GetAPIKeysByUserId(c.Request().Context(), userID)
+	if err != nil {
+		return problem.DBError(c, err)
+	}
+
+	// 다른 사용자의 key 는 없는 key 로 취급, 이미 폐기된 key 는 그대로 성공
+	for _, key := range keys {
+		if key.ID != uint(id) {
+			continue
+		}
+		if err := s.db.RevokeAPIKey(c.Request().Context(), key.ID); err != nil && !errors.Is(err, model.ErrConflict) {
+			return problem.DBError(c, err)
+		}
+		return c.NoContent(http.StatusNoContent)
+	}
+
+	return problem.JSON(c, http.StatusNotFound, "api key not found")
+}
 
-	return c.JSON(http.StatusCreated, token)
+// @Summary		jwks
+// @Description	public keys to verify access token (RS256/ES256), empty when HS256 is used
+// @name		jwks
+// @Produce		json
+// @Success		200	{object}	security.JWKS
+// @Router		/.well-known/jwks.json [get]
+func (s *SecurityHandler) jwks(c echo.Context) error {
+	return c.JSON(http.StatusOK, s.keys.JWKS())
 }
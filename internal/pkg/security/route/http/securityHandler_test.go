@@ -2,10 +2,28 @@ package http
 
 import (
 	"backend/config"
+	"backend/internal/pkg/domain"
+	githubRoute "backend/internal/pkg/github/route/http"
+	k8sRoute "backend/internal/pkg/k8s/route/http"
+	"backend/internal/pkg/model"
+	"backend/internal/pkg/security"
+	userRoute "backend/internal/pkg/user/route/http"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/golang-jwt/jwt/v4"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 )
@@ -15,18 +33,640 @@ func TestSecurityHandler(t *testing.T) {
 	e := echo.New()
 	cfg := config.Config{
 		JWTSigningKey: "jwtkey",
+		SqliteDBPath:  t.TempDir() + "/gorm.db",
+		AdminName:     "admin",
+		AdminPassword: "admin-secret",
 	}
 
-	req := httptest.NewRequest(http.MethodGet, "/", nil)
-	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
+	db, err := model.NewDBHandler(cfg)
+	assert.NoError(t, err)
 
-	sh := NewSecurityHandler(e, cfg)
+	sh, err := NewSecurityHandler(e, cfg, db)
+	assert.NoError(t, err)
 
-	// Assertions
-	if assert.NoError(t, sh.getAccessToken(c)) {
-		assert.Equal(t, http.StatusCreated, rec.Code)
+	hash, _ := security.HashPassword("secret")
+	assert.NoError(t, sh.db.AddUser(context.Background(), &model.User{Name: "a", PasswordHash: hash}))
+
+	login := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		assert.NoError(t, sh.login(c))
+		return rec
+	}
+
+	// 1. 정상 login
+	rec := login(`{"name":"a","password":"secret"}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	token := &security.Token{}
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(token))
+	assert.NotEmpty(t, token.Token)
+
+	// 2. password 오류, 없는 사용자 모두 401
+	rec = login(`{"name":"a","password":"wrong"}`)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = login(`{"name":"nobody","password":"secret"}`)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = login(`{}`)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	// 마지막 login 시간 기록
+	user, err := sh.db.GetUserByName(context.Background(), "a")
+	assert.NoError(t, err)
+	assert.NotNil(t, user.LastLoginAt)
+
+	// 비활성 사용자는 password 가 맞아도 403
+	assert.NoError(t, sh.db.UpdateUserById(context.Background(), int(user.ID), &model.User{Status: model.UserLocked}))
+	rec = login(`{"name":"a","password":"secret"}`)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	// 설정된 admin 계정 admin role 로 생성
+	rec = login(`{"name":"admin","password":"admin-secret"}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	admin, err := sh.db.GetUserByName(context.Background(), "admin")
+	assert.NoError(t, err)
+	assert.True(t, admin.Roles.Has(security.RoleAdmin))
+
+	// 3. 발급된 token 으로 인증 필요 API 호출 시 actor 확인
+	assert.NoError(t, security.WebSecurityConfig(e, cfg, db))
+	e.GET("/api/v1/me", func(c echo.Context) error {
+		return c.String(http.StatusOK, security.Actor(c))
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/me", nil)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+token.Token)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "a", rec.Body.String())
+
+	// HS256 사용 시 공개할 key 없음, token 없이 조회 가능
+	req = httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"keys":[]}`, rec.Body.String())
+}
+
+func TestRefreshToken(t *testing.T) {
+
+	e := echo.New()
+	cfg := config.Config{
+		JWTSigningKey: "jwtkey",
+		SqliteDBPath:  t.TempDir() + "/gorm.db",
+		AdminName:     "admin",
+		AdminPassword: "admin-secret",
+	}
+
+	db, err := model.NewDBHandler(cfg)
+	assert.NoError(t, err)
+
+	_, err = NewSecurityHandler(e, cfg, db)
+	assert.NoError(t, err)
+	assert.NoError(t, security.WebSecurityConfig(e, cfg, db))
+	e.GET("/api/v1/me", func(c echo.Context) error {
+		return c.String(http.StatusOK, security.Actor(c))
+	})
+
+	call := func(method, path, accessToken, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		if accessToken != "" {
+			req.Header.Set(echo.HeaderAuthorization, "Bearer "+accessToken)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	decode := func(rec *httptest.ResponseRecorder) *security.Token {
+		token := &security.Token{}
+		assert.NoError(t, json.NewDecoder(rec.Body).Decode(token))
+		return token
+	}
+
+	// 1. login 시 access/refresh token 발급
+	rec := call(http.MethodPost, "/api/v1/login", "", `{"name":"admin","password":"admin-secret"}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	first := decode(rec)
+	assert.NotEmpty(t, first.RefreshToken)
+	assert.Equal(t, int64(15*60), first.ExpiresIn)
+
+	// 2. refresh 시 새 refresh token 으로 rotation
+	rec = call(http.MethodPost, "/api/v1/token/refresh", "", `{"refreshToken":"`+first.RefreshToken+`"}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	second := decode(rec)
+	assert.NotEqual(t, first.RefreshToken, second.RefreshToken)
+
+	rec = call(http.MethodGet, "/api/v1/me", second.Token, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "admin", rec.Body.String())
+
+	// 3. 이미 사용한 token 재사용 시 401, 같은 family 의 token 도 폐기
+	rec = call(http.MethodPost, "/api/v1/token/refresh", "", `{"refreshToken":"`+first.RefreshToken+`"}`)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = call(http.MethodPost, "/api/v1/token/refresh", "", `{"refreshToken":"`+second.RefreshToken+`"}`)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = call(http.MethodPost, "/api/v1/token/refresh", "", `{"refreshToken":"unknown"}`)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	// 4. logout 후 access token, refresh token 모두 사용 불가
+	rec = call(http.MethodPost, "/api/v1/login", "", `{"name":"admin","password":"admin-secret"}`)
+	third := decode(rec)
+
+	rec = call(http.MethodPost, "/api/v1/logout", third.Token, `{"refreshToken":"`+third.RefreshToken+`"}`)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	rec = call(http.MethodGet, "/api/v1/me", third.Token, "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = call(http.MethodPost, "/api/v1/token/refresh", "", `{"refreshToken":"`+third.RefreshToken+`"}`)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	// 5. revoke 시 사용자의 모든 refresh token 폐기
+	rec = call(http.MethodPost, "/api/v1/login", "", `{"name":"admin","password":"admin-secret"}`)
+	fourth := decode(rec)
+	rec = call(http.MethodPost, "/api/v1/login", "", `{"name":"admin","password":"admin-secret"}`)
+	fifth := decode(rec)
+
+	rec = call(http.MethodPost, "/api/v1/token/revoke", fifth.Token, "")
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	rec = call(http.MethodPost, "/api/v1/token/refresh", "", `{"refreshToken":"`+fourth.RefreshToken+`"}`)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = call(http.MethodPost, "/api/v1/token/revoke", "", "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+// 테스트용 oidc idp, login 요청의 nonce/code_challenge 를 기억해 token 응답에 사용
+type stubIdP struct {
+	*httptest.Server
+	key       *rsa.PrivateKey
+	clientID  string
+	challenge string
+	nonce     string
+	subject   string
+	name      string
+	email     string
+	groups    []string
+	// id token header 의 kid, 비어있으면 idp-1
+	kid string
+	// jwks 조회 횟수
+	jwksCalls int32
+}
+
+func newStubIdP(t *testing.T, clientID string) *stubIdP {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	idp := &stubIdP{key: key, clientID: clientID}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 idp.URL,
+			"authorization_endpoint": idp.URL + "/authorize",
+			"token_endpoint":         idp.URL + "/token",
+			"jwks_uri":               idp.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&idp.jwksCalls, 1)
+		json.NewEncoder(w).Encode(&security.JWKS{Keys: []security.JWK{{
+			Kty: "RSA", Kid: "idp-1", Use: "sig", Alg: "RS256",
+			N: base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E: "AQAB",
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+
+		// PKCE: code_verifier 의 S256 hash 가 login 요청의 code_challenge 와 같아야 함
+		sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+		if r.PostForm.Get("code") != "code-1" || base64.RawURLEncoding.EncodeToString(sum[:]) != idp.challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+
+		now := time.Now()
+		idToken := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+			"iss":                idp.URL,
+			"aud":                idp.clientID,
+			"sub":                idp.subject,
+			"nonce":              idp.nonce,
+			"preferred_username": idp.name,
+			"email":              idp.email,
+			"groups":             idp.groups,
+			"iat":                now.Unix(),
+			"exp":                now.Add(time.Minute).Unix(),
+		})
+		idToken.Header["kid"] = "idp-1"
+		if idp.kid != "" {
+			idToken.Header["kid"] = idp.kid
+		}
+		signed, _ := idToken.SignedString(key)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "idp-access-token",
+			"token_type":   "Bearer",
+			"expires_in":   60,
+			"id_token":     signed,
+		})
+	})
+	idp.Server = httptest.NewServer(mux)
+	t.Cleanup(idp.Close)
+
+	return idp
+}
+
+func TestOIDCLogin(t *testing.T) {
+
+	idp := newStubIdP(t, "go-echo")
+
+	e := echo.New()
+	cfg := config.Config{
+		JWTSigningKey: "jwtkey",
+		SqliteDBPath:  t.TempDir() + "/gorm.db",
+		AdminName:     "admin",
+		AdminPassword: "admin-secret",
+		OIDC: config.OIDC{
+			Enabled:     true,
+			Issuer:      idp.URL,
+			ClientID:    "go-echo",
+			RedirectURL: "http://localhost:1323/api/v1/login/oidc/callback",
+			RoleMapping: map[string]string{"platform-admin": "admin", "dev": "developer"},
+		},
+	}
+
+	db, err := model.NewDBHandler(cfg)
+	assert.NoError(t, err)
+
+	sh, err := NewSecurityHandler(e, cfg, db)
+	assert.NoError(t, err)
+	assert.NoError(t, security.WebSecurityConfig(e, cfg, db))
+
+	call := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	// idp 로 redirect 후 callback 호출
+	login := func() (*httptest.ResponseRecorder, string) {
+		rec := call("/api/v1/login/oidc")
+		assert.Equal(t, http.StatusFound, rec.Code)
+
+		location, err := url.Parse(rec.Header().Get(echo.HeaderLocation))
+		assert.NoError(t, err)
+		assert.Equal(t, idp.URL+"/authorize", location.Scheme+"://"+location.Host+location.Path)
+
+		query := location.Query()
+		assert.Equal(t, "S256", query.Get("code_challenge_method"))
+		assert.Equal(t, "go-echo", query.Get("client_id"))
+		idp.challenge, idp.nonce = query.Get("code_challenge"), query.Get("nonce")
+
+		state := query.Get("state")
+		return call("/api/v1/login/oidc/callback?code=code-1&state=" + state), state
+	}
+
+	// 1. 최초 login 시 사용자 생성, group 으로 role 매핑
+	idp.subject, idp.name, idp.email, idp.groups = "sub-1", "kim", "Kim@Example.com", []string{"dev", "unknown"}
+	rec, state := login()
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	token := &security.Token{}
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(token))
+	assert.NotEmpty(t, token.Token)
+	assert.NotEmpty(t, token.RefreshToken)
+
+	user, err := sh.db.GetUserByExternalID(context.Background(), idp.URL+"|sub-1")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "kim", user.Name)
+		assert.Equal(t, "kim@example.com", user.Email)
+		assert.Equal(t, model.Roles{security.RoleDeveloper}, user.Roles)
+	}
+
+	// 발급한 token 으로 인증
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/logout", nil)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+token.Token)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	// 2. 같은 state 재사용 불가
+	rec = call("/api/v1/login/oidc/callback?code=code-1&state=" + state)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	// 3. 다시 login 시 같은 사용자의 role, email 갱신
+	idp.email, idp.groups = "kim@corp.example.com", []string{"platform-admin"}
+	rec, _ = login()
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	updated, err := sh.db.GetUserByExternalID(context.Background(), idp.URL+"|sub-1")
+	if assert.NoError(t, err) {
+		assert.Equal(t, user.ID, updated.ID)
+		assert.Equal(t, "kim@corp.example.com", updated.Email)
+		assert.Equal(t, model.Roles{security.RoleAdmin}, updated.Roles)
+	}
+
+	// 4. 로컬 사용자와 이름이나 email 이 같으면 409
+	idp.subject, idp.name, idp.email, idp.groups = "sub-2", "admin", "", nil
+	rec, _ = login()
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	idp.subject, idp.name, idp.email = "sub-3", "lee", "kim@corp.example.com"
+	rec, _ = login()
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	// 모르는 kid 는 jwks 를 매번 다시 조회하지 않음
+	assert.Equal(t, int32(1), atomic.LoadInt32(&idp.jwksCalls))
+	idp.subject, idp.name, idp.email, idp.kid = "sub-4", "park", "", "forged"
+	for i := 0; i < 3; i++ {
+		rec, _ = login()
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&idp.jwksCalls))
+	idp.kid = ""
+
+	// 5. PKCE 검증 실패 (idp 가 code 교환 거부) 시 502, nonce 불일치 시 401
+	rec = call("/api/v1/login/oidc")
+	location, _ := url.Parse(rec.Header().Get(echo.HeaderLocation))
+	idp.challenge, idp.nonce = "other", location.Query().Get("nonce")
+	rec = call("/api/v1/login/oidc/callback?code=code-1&state=" + location.Query().Get("state"))
+	assert.Equal(t, http.StatusBadGateway, rec.Code)
+
+	rec = call("/api/v1/login/oidc")
+	location, _ = url.Parse(rec.Header().Get(echo.HeaderLocation))
+	idp.challenge, idp.nonce = location.Query().Get("code_challenge"), "other"
+	rec = call("/api/v1/login/oidc/callback?code=code-1&state=" + location.Query().Get("state"))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = call("/api/v1/login/oidc/callback?error=access_denied")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestAPIKey(t *testing.T) {
+
+	e := echo.New()
+	cfg := config.Config{
+		JWTSigningKey: "jwtkey",
+		SqliteDBPath:  t.TempDir() + "/gorm.db",
+	}
+
+	db, err := model.NewDBHandler(cfg)
+	assert.NoError(t, err)
+
+	sh, err := NewSecurityHandler(e, cfg, db)
+	assert.NoError(t, err)
+	assert.NoError(t, security.WebSecurityConfig(e, cfg, db))
+
+	ok := func(c echo.Context) error { return c.String(http.StatusOK, security.Actor(c)) }
+	e.GET("/api/v1/github/a", ok, security.RequirePermission(security.PermRepoRead))
+	e.POST("/api/v1/github/a", ok, security.RequirePermission(security.PermRepoWrite))
+
+	hash, _ := security.HashPassword("secret")
+	assert.NoError(t, sh.db.AddUser(context.Background(), &model.User{Name: "ci", PasswordHash: hash, Roles: model.Roles{security.RoleDeveloper}}))
+
+	call := func(method, path, header, auth, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		if auth != "" {
+			req.Header.Set(header, auth)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := call(http.MethodPost, "/api/v1/login", "", "", `{"name":"ci","password":"secret"}`)
+	token := &security.Token{}
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(token))
+	bearer := "Bearer " + token.Token
+
+	// 1. scope 와 함께 생성, 원문은 생성 응답에만 포함
+	rec = call(http.MethodPost, "/api/v1/apikeys", echo.HeaderAuthorization, bearer, `{"name":"ci","scopes":["repo:read"]}`)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	created := &security.CreateAPIKeyResponse{}
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(created))
+	assert.Len(t, created.Prefix, 16)
+	assert.True(t, strings.HasPrefix(created.Key, "ak_"+created.Prefix+"_"))
+	assert.Equal(t, model.Scopes{security.PermRepoRead}, created.Scopes)
+	assert.NotNil(t, created.ExpiresAt)
+
+	// 본인에게 없는 권한, 알 수 없는 권한, 지난 만료 시각은 거부
+	rec = call(http.MethodPost, "/api/v1/apikeys", echo.HeaderAuthorization, bearer, `{"name":"ci","scopes":["repo:delete"]}`)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	rec = call(http.MethodPost, "/api/v1/apikeys", echo.HeaderAuthorization, bearer, `{"name":"ci","scopes":["root"]}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	rec = call(http.MethodPost, "/api/v1/apikeys", echo.HeaderAuthorization, bearer, `{"name":"ci","expiresAt":"2000-01-01T00:00:00Z"}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	// 2. api key 로 인증, Authorization 또는 X-API-Key header
+	rec = call(http.MethodGet, "/api/v1/github/a", echo.HeaderAuthorization, "Bearer "+created.Key, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "ci", rec.Body.String())
+
+	rec = call(http.MethodGet, "/api/v1/github/a", "X-API-Key", created.Key, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// role 에 있는 권한이라도 scope 에 없으면 403
+	rec = call(http.MethodPost, "/api/v1/github/a", "X-API-Key", created.Key, "")
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	// api key 로 api key 생성 불가
+	rec = call(http.MethodPost, "/api/v1/apikeys", "X-API-Key", created.Key, `{"name":"ci2"}`)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	rec = call(http.MethodGet, "/api/v1/github/a", "X-API-Key", "ak_"+created.Prefix+"_wrong", "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	// 3. 목록 조회 시 hash, 원문 미포함
+	rec = call(http.MethodGet, "/api/v1/apikeys", echo.HeaderAuthorization, bearer, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotContains(t, rec.Body.String(), created.Key)
+	assert.NotContains(t, rec.Body.String(), "keyHash")
+
+	keys := []*model.APIKey{}
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&keys))
+	assert.Equal(t, 1, len(keys))
+
+	// 4. 폐기 후 사용 불가
+	id := strconv.FormatUint(uint64(created.ID), 10)
+	rec = call(http.MethodDelete, "/api/v1/apikeys/"+id, echo.HeaderAuthorization, bearer, "")
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	rec = call(http.MethodGet, "/api/v1/github/a", "X-API-Key", created.Key, "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = call(http.MethodDelete, "/api/v1/apikeys/"+id, echo.HeaderAuthorization, bearer, "")
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	rec = call(http.MethodDelete, "/api/v1/apikeys/999", echo.HeaderAuthorization, bearer, "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	// 5. 만료된 key 사용 불가
+	user, err := sh.db.GetUserByName(context.Background(), "ci")
+	assert.NoError(t, err)
+	expiresAt := time.Now().Add(-time.Minute)
+	expired, err := sh.apiKeys.Create(context.Background(), user.ID, &security.CreateAPIKeyRequest{Name: "old", ExpiresAt: &expiresAt})
+	assert.NoError(t, err)
+
+	rec = call(http.MethodGet, "/api/v1/github/a", "X-API-Key", expired.Key, "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestOnlyPublicRoutesSkipAuth(t *testing.T) {
+
+	e := echo.New()
+	cfg := config.Config{
+		JWTSigningKey: "jwtkey",
+		SqliteDBPath:  t.TempDir() + "/gorm.db",
+		OIDC:          config.OIDC{Enabled: true, Issuer: "http://127.0.0.1:1"},
+		PublicRoutes: []config.PublicRoute{
+			{Method: "GET", Path: "/swagger/**"},
+		},
+	}
+
+	db, err := model.NewDBHandler(cfg)
+	assert.NoError(t, err)
+	audit := domain.NewAuditPublisher(&domain.LogMessageSender{}, cfg)
+
+	assert.NoError(t, security.WebSecurityConfig(e, cfg, db))
+	_, err = NewSecurityHandler(e, cfg, db)
+	assert.NoError(t, err)
+	userRoute.NewUserHandler(e, cfg, db, audit)
+	githubRoute.NewGitHandler(e, cfg, audit)
+	k8sRoute.NewK8sHandler(e, cfg, audit)
+	e.GET("/swagger/*", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+
+	call := func(method, path string) int {
+		req := httptest.NewRequest(method, path, nil)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// route 등록 시 public 으로 표시한 route 외에는 모두 token 필요
+	public := map[string]bool{
+		"POST /api/v1/login":              true,
+		"GET /api/v1/login/oidc":          true,
+		"GET /api/v1/login/oidc/callback": true,
+		"POST /api/v1/token/refresh":      true,
+		"GET /.well-known/jwks.json":      true,
+		"GET /swagger/*":                  true,
+	}
+	for _, route := range e.Routes() {
+		if public[route.Method+" "+route.Path] {
+			continue
+		}
+
+		path := route.Path
+		for _, segment := range strings.Split(route.Path, "/") {
+			if strings.HasPrefix(segment, ":") || segment == "*" {
+				path = strings.Replace(path, segment, "x", 1)
+			}
+		}
+		assert.Equal(t, http.StatusUnauthorized, call(route.Method, path), route.Method+" "+route.Path)
+	}
+
+	assert.Equal(t, http.StatusOK, call(http.MethodGet, "/.well-known/jwks.json"))
+	assert.Equal(t, http.StatusOK, call(http.MethodGet, "/swagger/index.html"))
+
+	// public 경로와 비슷하지만 다른 요청은 인증 필요
+	for _, path := range []string{
+		"/api/v1/login/../user",
+		"/swagger/../api/v1/user",
+		"/swagger//../api/v1/user",
+		"/api/v1/user?x=/swagger/index.html",
+		"/API/V1/USER",
+	} {
+		assert.Equal(t, http.StatusUnauthorized, call(http.MethodGet, path), path)
+	}
+	assert.Equal(t, http.StatusUnauthorized, call(http.MethodPost, "/swagger/index.html"))
+	assert.Equal(t, http.StatusUnauthorized, call(http.MethodDelete, "/api/v1/login"))
+}
+
+func TestCreateAdmin(t *testing.T) {
+	ctx := context.Background()
+	cfg := config.Config{
+		JWTSigningKey: "jwtkey",
+		SqliteDBPath:  t.TempDir() + "/gorm.db",
+	}
+
+	db, err := model.NewDBHandler(cfg)
+	assert.NoError(t, err)
+
+	// 미설정 시 생성하지 않음
+	_, err = NewSecurityHandler(echo.New(), cfg, db)
+	assert.NoError(t, err)
+	_, err = db.GetUserByName(ctx, "admin")
+	assert.ErrorIs(t, err, model.ErrNotFound)
+
+	// password 누락, 기본 password 는 시작하지 않음
+	for _, admin := range [][2]string{{"admin", ""}, {"admin", "admin"}, {"root", "root"}, {"admin", "ChangeMe"}, {"", "admin-secret"}} {
+		cfg.AdminName, cfg.AdminPassword = admin[0], admin[1]
+		_, err = NewSecurityHandler(echo.New(), cfg, db)
+		assert.Error(t, err, admin)
 	}
 
-	t.Log(rec.Body.String())
+	cfg.AdminName, cfg.AdminPassword = "admin", "admin-secret"
+	_, err = NewSecurityHandler(echo.New(), cfg, db)
+	assert.NoError(t, err)
+	admin, err := db.GetUserByName(ctx, "admin")
+	assert.NoError(t, err)
+	assert.Equal(t, model.Roles{security.RoleAdmin}, admin.Roles)
+
+	// 재시작 시 운영자가 변경한 role 을 되돌리지 않음
+	assert.NoError(t, db.UpdateUserById(ctx, int(admin.ID), &model.User{Roles: model.Roles{security.RoleViewer}}))
+	_, err = NewSecurityHandler(echo.New(), cfg, db)
+	assert.NoError(t, err)
+	admin, err = db.GetUserByName(ctx, "admin")
+	assert.NoError(t, err)
+	assert.Equal(t, model.Roles{security.RoleViewer}, admin.Roles)
+}
+
+func TestLoginLockout(t *testing.T) {
+
+	e := echo.New()
+	cfg := config.Config{
+		JWTSigningKey: "jwtkey",
+		SqliteDBPath:  t.TempDir() + "/gorm.db",
+		AdminName:     "admin",
+		AdminPassword: "admin-secret",
+		RateLimit:     config.RateLimit{LoginMaxFailures: 3, LoginLockout: time.Minute},
+	}
+
+	db, err := model.NewDBHandler(cfg)
+	assert.NoError(t, err)
+
+	_, err = NewSecurityHandler(e, cfg, db)
+	assert.NoError(t, err)
+
+	login := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/login", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, http.StatusUnauthorized, login(`{"name":"admin","password":"wrong"}`).Code)
+	}
+
+	// 잠긴 동안은 올바른 password 도 거부
+	rec := login(`{"name":"admin","password":"admin-secret"}`)
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "60", rec.Header().Get(echo.HeaderRetryAfter))
+
+	// 다른 계정은 영향 없음
+	assert.Equal(t, http.StatusUnauthorized, login(`{"name":"other","password":"wrong"}`).Code)
 }
@@ -2,6 +2,10 @@ package security
 
 import (
 	"backend/config"
+	"backend/internal/pkg/model"
+	"context"
+	"errors"
+	"strconv"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
@@ -13,21 +17,26 @@ import (
 
 // JwtCustomClaims are custom claims extending default ones.
 type jwtCustomClaims struct {
-	// ID   string `json:"id"`
-	// Name string `json:"name"`
+	ID    uint     `json:"id"`
+	Name  string   `json:"name"`
+	Roles []string `json:"roles"`
+	// api key 인증 시에만 설정, roles 의 권한 중 scopes 에 있는 권한만 허용
+	Scopes []string `json:"scopes,omitempty"`
+	// api key 인증 시 key prefix, 요청 제한 key 로 사용
+	apiKeyPrefix string
 	jwt.RegisteredClaims
 }
 
 type Token struct {
-	Token string `json:"token"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refreshToken,omitempty"`
+	// access token 유효 기간(초)
+	ExpiresIn int64 `json:"expiresIn,omitempty"`
 }
 
-var whiteListPaths = []string{
-	"/favicon.ico",
-	"/swagger/*",
-	"/api/v1/login",
-	// "/api/*",
-	// "/api/v1/signup",
+type LoginRequest struct {
+	Name     string `json:"name"`
+	Password string `json:"password"`
 }
 
 func init() {
@@ -35,45 +44,131 @@ func init() {
 	middleware.ErrJWTMissing.Message = "Unauthorized"
 }
 
-func skipAuth(c echo.Context) bool {
-	// Skip authentication for and signup login requests
-	for _, path := range whiteListPaths {
-		if path == c.Request().URL.Path || path == c.Path() {
-			return true
-		}
+func WebSecurityConfig(e *echo.Echo, cfg config.Config, db model.DBHandler) error {
+
+	keys, err := NewKeySet(cfg)
+	if err != nil {
+		return err
 	}
-	return false
-}
+	apiKeys := NewAPIKeyService(cfg, db)
 
-func WebSecurityConfig(e *echo.Echo, cfg config.Config) {
+	rules, err := newPublicRules(cfg.PublicRoutes)
+	if err != nil {
+		return err
+	}
 
 	config := echojwt.Config{
-		NewClaimsFunc: func(c echo.Context) jwt.Claims {
-			return new(jwtCustomClaims)
+		// jwt 또는 api key, api key 는 X-API-Key header 로도 전달 가능
+		TokenLookup: "header:" + echo.HeaderAuthorization + ":Bearer ,header:X-API-Key",
+		ParseTokenFunc: func(c echo.Context, auth string) (interface{}, error) {
+			ctx := c.Request().Context()
+			if IsAPIKey(auth) {
+				return apiKeys.Authenticate(ctx, auth)
+			}
+			return parseToken(ctx, keys, db, auth)
 		},
-		SigningKey: []byte(cfg.JWTSigningKey),
-		Skipper:    skipAuth,
+		Skipper: newSkipper(rules),
 	}
 	e.Use(echojwt.WithConfig(config))
+
+	return nil
+}
+
+// 서명, 만료 확인 후 logout 등으로 폐기된 token 인지 확인
+func parseToken(ctx context.Context, keys *KeySet, db model.DBHandler, auth string) (*jwt.Token, error) {
+	token, err := jwt.ParseWithClaims(auth, new(jwtCustomClaims), keys.Keyfunc)
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+
+	claims := token.Claims.(*jwtCustomClaims)
+	if jti := claims.RegisteredClaims.ID; jti != "" {
+		revoked, err := db.IsTokenRevoked(ctx, jti)
+		if err != nil {
+			return nil, err
+		}
+		if revoked {
+			return nil, ErrTokenRevoked
+		}
+	}
+
+	return token, nil
+}
+
+// 인증된 요청의 claims, 없으면 nil
+func claimsFrom(c echo.Context) *jwtCustomClaims {
+	token, ok := c.Get("user").(*jwt.Token)
+	if !ok {
+		return nil
+	}
+	claims, ok := token.Claims.(*jwtCustomClaims)
+	if !ok {
+		return nil
+	}
+	return claims
+}
+
+// 인증된 사용자 id
+func UserID(c echo.Context) (uint, bool) {
+	claims := claimsFrom(c)
+	if claims == nil {
+		return 0, false
+	}
+	return claims.ID, true
+}
+
+// api key 로 인증된 요청인지 확인
+func AuthenticatedByAPIKey(c echo.Context) bool {
+	claims := claimsFrom(c)
+	return claims != nil && claims.Scopes != nil
+}
+
+// audit 기록용 요청자, token 의 name 이 없으면 anonymous
+func Actor(c echo.Context) string {
+	claims := claimsFrom(c)
+	if claims == nil || claims.Name == "" {
+		return "anonymous"
+	}
+	return claims.Name
 }
 
-func JsonWebTokenIssuer(cfg config.Config) string {
+func JsonWebTokenIssuer(cfg config.Config, id uint, name string, roles ...string) (string, error) {
+	keys, err := NewKeySet(cfg)
+	if err != nil {
+		return "", err
+	}
+	return issueAccessToken(cfg, keys, id, name, roles)
+}
+
+func issueAccessToken(cfg config.Config, keys *KeySet, id uint, name string, roles []string) (string, error) {
+	jti, err := randomToken(16)
+	if err != nil {
+		return "", err
+	}
+
 	// Set custom claims
+	now := time.Now()
 	claims := &jwtCustomClaims{
-		jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour * 72)),
+		ID:    id,
+		Name:  name,
+		Roles: roles,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
+			Subject:   strconv.FormatUint(uint64(id), 10),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(accessTokenTTL(cfg))),
 		},
 	}
 
-	// Create token with claims
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-
 	// Generate encoded token and send it as response.
-	t, err := token.SignedString([]byte(cfg.JWTSigningKey))
+	t, err := keys.Sign(claims)
 	if err != nil {
 		log.Warn(err)
+		return "", err
 	}
 
-	return t
-
+	return t, nil
 }
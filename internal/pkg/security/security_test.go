@@ -2,8 +2,14 @@ package security
 
 import (
 	"backend/config"
+	"backend/internal/pkg/model"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -13,8 +19,77 @@ func TestJsonWebTokenIssuer(t *testing.T) {
 		JWTSigningKey: "signingkey",
 	}
 
-	token := JsonWebTokenIssuer(cfg)
+	token, err := JsonWebTokenIssuer(cfg, 1, "a", RoleDeveloper)
 	t.Log(token)
 
-	assert.NotNil(token)
+	assert.NoError(err)
+	assert.NotEmpty(token)
+
+	// claims 에 id, name, roles 포함
+	claims := &jwtCustomClaims{}
+	_, err = jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		return []byte(cfg.JWTSigningKey), nil
+	})
+	assert.NoError(err)
+	assert.Equal(uint(1), claims.ID)
+	assert.Equal("a", claims.Name)
+	assert.Equal([]string{RoleDeveloper}, claims.Roles)
+	assert.Equal("1", claims.Subject)
+}
+
+func TestPassword(t *testing.T) {
+	assert := assert.New(t)
+
+	hash, err := HashPassword("secret")
+	assert.NoError(err)
+	assert.NotEqual("secret", hash)
+
+	assert.True(CheckPassword(hash, "secret"))
+	assert.False(CheckPassword(hash, "wrong"))
+	assert.False(CheckPassword("", "secret"))
+}
+
+func TestRequirePermission(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	cfg := config.Config{
+		JWTSigningKey: "signingkey",
+		SqliteDBPath:  t.TempDir() + "/gorm.db",
+	}
+	db, err := model.NewDBHandler(cfg)
+	assert.NoError(err)
+	WebSecurityConfig(e, cfg, db)
+	e.DELETE("/api/v1/github/:owner/:repo", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}, RequirePermission(PermRepoDelete))
+
+	call := func(roles ...string) *httptest.ResponseRecorder {
+		token, _ := JsonWebTokenIssuer(cfg, 1, "a", roles...)
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/github/a/b", nil)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	assert.Equal(http.StatusOK, call(RoleAdmin).Code)
+	assert.Equal(http.StatusOK, call(RoleViewer, RoleAdmin).Code)
+
+	// 권한 부족 시 403, 필요한 권한 포함
+	for _, roles := range [][]string{{RoleDeveloper}, {RoleViewer}, {}} {
+		rec := call(roles...)
+		assert.Equal(http.StatusForbidden, rec.Code)
+
+		body := &ForbiddenResponse{}
+		assert.NoError(json.NewDecoder(rec.Body).Decode(body))
+		assert.Equal(&ForbiddenResponse{Message: "forbidden", Permission: PermRepoDelete}, body)
+	}
+
+	assert.True(HasPermission([]string{RoleDeveloper}, PermK8sWrite))
+	assert.False(HasPermission([]string{RoleViewer}, PermK8sWrite))
+	assert.Equal([]string{RoleViewer}, UserRoles(&model.User{}))
+
+	assert.NoError(ValidateRoles([]string{RoleAdmin, RoleViewer}))
+	assert.Error(ValidateRoles([]string{"root"}))
 }
@@ -0,0 +1,215 @@
+package security
+
+import (
+	"backend/config"
+	"backend/internal/pkg/model"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	ErrTokenRevoked        = errors.New("token revoked")
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	// 이미 rotation 된 refresh token 이 다시 사용됨, 같은 family 전체 폐기
+	ErrRefreshTokenReused = errors.New("refresh token reused")
+	// disabled, locked 사용자
+	ErrUserInactive = errors.New("user is not active")
+)
+
+// ttl 미설정(config.New 를 거치지 않은 경우) 시 기본값
+const (
+	defaultAccessTokenTTL  = 15 * time.Minute
+	defaultRefreshTokenTTL = 30 * 24 * time.Hour
+)
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refreshToken"`
+}
+
+func accessTokenTTL(cfg config.Config) time.Duration {
+	if cfg.AccessTokenTTL <= 0 {
+		return defaultAccessTokenTTL
+	}
+	return cfg.AccessTokenTTL
+}
+
+func refreshTokenTTL(cfg config.Config) time.Duration {
+	if cfg.RefreshTokenTTL <= 0 {
+		return defaultRefreshTokenTTL
+	}
+	return cfg.RefreshTokenTTL
+}
+
+func randomToken(size int) (string, error) {
+	b := make([]byte, size)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// refresh token 은 원문 대신 hash 로 저장 및 조회
+func hashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// access/refresh token 발급, rotation 및 폐기
+type TokenService struct {
+	cfg  config.Config
+	db   model.DBHandler
+	keys *KeySet
+}
+
+func NewTokenService(cfg config.Config, db model.DBHandler, keys *KeySet) *TokenService {
+	return &TokenService{cfg: cfg, db: db, keys: keys}
+}
+
+// login 시 새 family 로 발급
+func (t *TokenService) Issue(ctx context.Context, user *model.User) (*Token, error) {
+	familyID, err := randomToken(16)
+	if err != nil {
+		return nil, err
+	}
+	return t.issue(ctx, user, familyID)
+}
+
+func (t *TokenService) issue(ctx context.Context, user *model.User, familyID string) (*Token, error) {
+	access, err := issueAccessToken(t.cfg, t.keys, user.ID, user.Name, UserRoles(user))
+	if err != nil {
+		return nil, err
+	}
+
+	refresh, err := randomToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	stored := &model.RefreshToken{
+		UserID:    user.ID,
+		TokenHash: hashRefreshToken(refresh),
+		FamilyID:  familyID,
+		ExpiresAt: time.Now().Add(refreshTokenTTL(t.cfg)),
+	}
+	if err := t.db.AddRefreshToken(ctx, stored); err != nil {
+		return nil, err
+	}
+
+	return &Token{
+		Token:        access,
+		RefreshToken: refresh,
+		ExpiresIn:    int64(accessTokenTTL(t.cfg).Seconds()),
+	}, nil
+}
+
+// 사용한 refresh token 은 폐기하고 같은 family 로 새로 발급
+func (t *TokenService) Refresh(ctx context.Context, refreshToken string) (*Token, error) {
+	if refreshToken == "" {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	stored, err := t.db.GetRefreshTokenByHash(ctx, hashRefreshToken(refreshToken))
+	if errors.Is(err, model.ErrNotFound) {
+		return nil, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// 폐기된 token 재사용은 탈취 가능성이 있으므로 family 전체 폐기
+	if stored.RevokedAt != nil {
+		return nil, t.revokeReused(ctx, stored.FamilyID)
+	}
+	if time.Now().After(stored.ExpiresAt) {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	// 동시에 같은 token 으로 요청한 경우 하나만 성공
+	err = t.db.RevokeRefreshToken(ctx, stored.ID)
+	if errors.Is(err, model.ErrConflict) {
+		return nil, t.revokeReused(ctx, stored.FamilyID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := t.db.GetUserById(ctx, int(stored.UserID))
+	if errors.Is(err, model.ErrNotFound) {
+		return nil, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !user.Active() {
+		return nil, ErrUserInactive
+	}
+
+	return t.issue(ctx, user, stored.FamilyID)
+}
+
+// family 폐기 실패 시 재사용 대신 db error 반환
+func (t *TokenService) revokeReused(ctx context.Context, familyID string) error {
+	if err := t.db.RevokeRefreshTokenFamily(ctx, familyID); err != nil {
+		return err
+	}
+	return ErrRefreshTokenReused
+}
+
+// refresh token 의 family 와 현재 access token 폐기
+func (t *TokenService) Logout(c echo.Context, refreshToken string) error {
+	claims := claimsFrom(c)
+	if claims == nil {
+		return ErrInvalidRefreshToken
+	}
+	ctx := c.Request().Context()
+
+	if refreshToken != "" {
+		stored, err := t.db.GetRefreshTokenByHash(ctx, hashRefreshToken(refreshToken))
+		if errors.Is(err, model.ErrNotFound) || (err == nil && stored.UserID != claims.ID) {
+			return ErrInvalidRefreshToken
+		}
+		if err != nil {
+			return err
+		}
+		if err := t.db.RevokeRefreshTokenFamily(ctx, stored.FamilyID); err != nil {
+			return err
+		}
+	}
+
+	return t.revokeAccessToken(ctx, claims)
+}
+
+// 사용자의 모든 refresh token 과 현재 access token 폐기
+func (t *TokenService) RevokeAll(c echo.Context) error {
+	claims := claimsFrom(c)
+	if claims == nil {
+		return ErrInvalidRefreshToken
+	}
+	ctx := c.Request().Context()
+
+	if err := t.db.RevokeUserRefreshTokens(ctx, claims.ID); err != nil {
+		return err
+	}
+	return t.revokeAccessToken(ctx, claims)
+}
+
+// 만료 시각까지만 폐기 목록에 유지
+func (t *TokenService) revokeAccessToken(ctx context.Context, claims *jwtCustomClaims) error {
+	jti := claims.RegisteredClaims.ID
+	if jti == "" {
+		return nil
+	}
+
+	expiresAt := time.Now().Add(accessTokenTTL(t.cfg))
+	if claims.ExpiresAt != nil {
+		expiresAt = claims.ExpiresAt.Time
+	}
+	return t.db.AddRevokedToken(ctx, &model.RevokedToken{JTI: jti, ExpiresAt: expiresAt})
+}
@@ -2,48 +2,86 @@ package http
 
 import (
 	"backend/config"
+	"backend/internal/pkg/domain"
 	model "backend/internal/pkg/model"
+	"backend/internal/pkg/problem"
+	"backend/internal/pkg/security"
+	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/labstack/echo/v4"
 )
 
 type UserHandler struct {
-	db model.DBHandler
+	db    model.DBHandler
+	audit *domain.AuditPublisher
 }
 
-func NewUserHandler(echo *echo.Echo, cfg config.Config) *UserHandler {
+func NewUserHandler(echo *echo.Echo, cfg config.Config, db model.DBHandler, audit *domain.AuditPublisher) *UserHandler {
 
 	handler := &UserHandler{
-		db: model.NewDBHandler(cfg),
+		db:    db,
+		audit: audit,
 	}
 
-	user := echo.Group("/api/v1/user")
+	user := echo.Group("/api/v1/user", security.RateLimit(cfg, "user"))
 	{
-		user.GET("", handler.getUsers)
-		user.GET("/:id", handler.getUsersById)
-		user.POST("", handler.createUser)
-		user.DELETE("/:id", handler.deleteUsersById)
-		user.PUT("/:id", handler.updateUserById)
+		user.GET("", handler.getUsers, security.RequirePermission(security.PermUserRead))
+		user.GET("/:id", handler.getUsersById, security.RequirePermission(security.PermUserRead))
+		user.POST("", handler.createUser, security.RequirePermission(security.PermUserWrite))
+		user.DELETE("/:id", handler.deleteUsersById, security.RequirePermission(security.PermUserWrite))
+		user.PUT("/:id", handler.updateUserById, security.RequirePermission(security.PermUserWrite))
 	}
 
 	return handler
 }
 
 // @Summary		Get users
-// @Description	Get all user's info
+// @Description	Get user's info with paging, filter and sort
 // @name		getUsers
 // @Accept		json
 // @Produce		json
-// @Success		200	{array}	model.User
+// @Param		page		query	int		false	"page number, starts at 1"
+// @Param		limit		query	int		false	"page size (default 20, max 100)"
+// @Param		cursor		query	string	false	"cursor of the next page, page is ignored"
+// @Param		sort		query	string	false	"sort field"	Enums(id, name, age, birthday, createdAt)
+// @Param		order		query	string	false	"sort direction"	Enums(asc, desc)
+// @Param		name		query	string	false	"name contains"
+// @Param		status		query	string	false	"user status"	Enums(active, disabled, locked)
+// @Param		minAge		query	int		false	"minimum age"
+// @Param		maxAge		query	int		false	"maximum age"
+// @Param		bornAfter	query	string	false	"birthday on or after (2006-01-02 or RFC3339)"
+// @Param		bornBefore	query	string	false	"birthday before (2006-01-02 or RFC3339)"
+// @Success		200	{object}	model.UserPage
+// @Failure		400	{object}	problem.Problem
 // @Router		/api/v1/user [get]
 // @Security    ApiKeyAuth
 func (u *UserHandler) getUsers(c echo.Context) error {
 
-	users := u.db.GetUsers()
+	query, err := userQueryFrom(c)
+	if err != nil {
+		return problem.JSON(c, http.StatusBadRequest, err.Error())
+	}
+
+	page, err := u.db.ListUsers(c.Request().Context(), query)
+	if err != nil {
+		return problem.DBError(c, err)
+	}
+
+	// 다음 페이지 주소는 현재 조건에 cursor 만 변경
+	if page.NextCursor != "" {
+		next := *c.Request().URL
+		params := next.Query()
+		params.Del("page")
+		params.Set("cursor", page.NextCursor)
+		next.RawQuery = params.Encode()
+		page.Next = next.RequestURI()
+		c.Response().Header().Set("Link", fmt.Sprintf(`<%s>; rel="next"`, page.Next))
+	}
 
-	return c.JSON(http.StatusOK, users)
+	return c.JSON(http.StatusOK, page)
 }
 
 // @Summary		Get user by id
@@ -52,7 +90,8 @@ func (u *UserHandler) getUsers(c echo.Context) error {
 // @Accept		json
 // @Produce		json
 // @Param		id	path		string	true	"id of the user"
-// @Success		200	{object}	model.User
+// @Success		200	{object}	model.UserResponse
+// @Failure		404	{object}	problem.Problem
 // @Router		/api/v1/user/{id} [get]
 // @Security    ApiKeyAuth
 func (u *UserHandler) getUsersById(c echo.Context) error {
@@ -64,9 +103,12 @@ func (u *UserHandler) getUsersById(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, c.Param("id"))
 	}
 
-	user := u.db.GetUserById(id)
+	user, err := u.db.GetUserById(c.Request().Context(), id)
+	if err != nil {
+		return problem.DBError(c, err)
+	}
 
-	return c.JSON(http.StatusOK, user)
+	return c.JSON(http.StatusOK, model.NewUserResponse(user))
 }
 
 // @Summary		Create user
@@ -74,25 +116,37 @@ func (u *UserHandler) getUsersById(c echo.Context) error {
 // @name		createUser
 // @Accept		json
 // @Produce		json
-// @Param		userBody	body	model.User	true	"User Info Body"
-// @Success		201
+// @Param		userBody	body		model.UserRequest	true	"User Info Body"
+// @Success		201			{object}	model.UserResponse
+// @Failure		409	{object}	problem.Problem
+// @Failure		422	{object}	problem.Problem
 // @Router		/api/v1/user [post]
 // @Security    ApiKeyAuth
 func (u *UserHandler) createUser(c echo.Context) error {
 
-	user := new(model.User)
+	req := new(model.UserRequest)
 
-	if err := c.Bind(user); err != nil {
+	if err := c.Bind(req); err != nil {
 		c.Error(err)
 		return c.JSON(http.StatusBadRequest, nil)
 	}
 
-	if cnt := u.db.AddUser(user); cnt == 1 {
-		return c.JSON(http.StatusCreated, nil)
+	if err := security.ValidateRoles(req.Roles); err != nil {
+		return problem.JSON(c, http.StatusUnprocessableEntity, err.Error())
 	}
 
-	return c.JSON(http.StatusBadRequest, nil)
+	user, err := userFrom(req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, nil)
+	}
 
+	if err := u.db.AddUser(c.Request().Context(), user); err != nil {
+		u.audit.Publish(security.Actor(c), "user.create", "user", err)
+		return problem.DBError(c, err)
+	}
+
+	u.audit.Publish(security.Actor(c), "user.create", fmt.Sprintf("user/%d", user.ID), nil)
+	return c.JSON(http.StatusCreated, model.NewUserResponse(user))
 }
 
 // @Summary		delete user by id
@@ -102,6 +156,7 @@ func (u *UserHandler) createUser(c echo.Context) error {
 // @Produce		json
 // @Param		id	path	string	true	"id of the user"
 // @Success		200
+// @Failure		404	{object}	problem.Problem
 // @Router		/api/v1/user/{id} [delete]
 // @Security    ApiKeyAuth
 func (u *UserHandler) deleteUsersById(c echo.Context) error {
@@ -113,10 +168,15 @@ func (u *UserHandler) deleteUsersById(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, c.Param("id"))
 	}
 
-	if cnt := u.db.DeleteUserById(id); cnt == 1 {
-		return c.JSON(http.StatusOK, nil)
+	target := fmt.Sprintf("user/%d", id)
+
+	if err := u.db.DeleteUserById(c.Request().Context(), id); err != nil {
+		u.audit.Publish(security.Actor(c), "user.delete", target, err)
+		return problem.DBError(c, err)
 	}
-	return c.JSON(http.StatusBadRequest, nil)
+
+	u.audit.Publish(security.Actor(c), "user.delete", target, nil)
+	return c.JSON(http.StatusOK, nil)
 }
 
 // @Summary		update user by id
@@ -124,9 +184,12 @@ func (u *UserHandler) deleteUsersById(c echo.Context) error {
 // @name		updateUserById
 // @Accept		json
 // @Produce		json
-// @Param		id			path	string		true	"id of the user"
-// @Param		userBody	body	model.User	true	"User Info Body"
+// @Param		id			path	string				true	"id of the user"
+// @Param		userBody	body	model.UserRequest	true	"User Info Body"
 // @Success		200
+// @Failure		404	{object}	problem.Problem
+// @Failure		409	{object}	problem.Problem
+// @Failure		422	{object}	problem.Problem
 // @Router		/api/v1/user/{id} [put]
 // @Security    ApiKeyAuth
 func (u *UserHandler) updateUserById(c echo.Context) error {
@@ -138,15 +201,136 @@ func (u *UserHandler) updateUserById(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, c.Param("id"))
 	}
 
-	user := new(model.User)
+	req := new(model.UserRequest)
 
-	if err := c.Bind(user); err != nil {
+	if err := c.Bind(req); err != nil {
 		c.Error(err)
 		return c.JSON(http.StatusBadRequest, nil)
 	}
 
-	if cnt := u.db.UpdateUserById(id, user); cnt == 1 {
-		return c.JSON(http.StatusOK, nil)
+	if err := security.ValidateRoles(req.Roles); err != nil {
+		return problem.JSON(c, http.StatusUnprocessableEntity, err.Error())
+	}
+
+	user, err := userFrom(req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, nil)
+	}
+
+	target := fmt.Sprintf("user/%d", id)
+
+	if err := u.db.UpdateUserById(c.Request().Context(), id, user); err != nil {
+		u.audit.Publish(security.Actor(c), "user.update", target, err)
+		return problem.DBError(c, err)
+	}
+
+	u.audit.Publish(security.Actor(c), "user.update", target, nil)
+	return c.JSON(http.StatusOK, nil)
+}
+
+// 요청 body 를 User 로 변환, password 는 bcrypt hash 로만 저장
+func userFrom(req *model.UserRequest) (*model.User, error) {
+	user := req.User()
+	if req.Password == "" {
+		return user, nil
+	}
+
+	hash, err := security.HashPassword(req.Password)
+	if err != nil {
+		return nil, err
+	}
+	user.PasswordHash = hash
+
+	return user, nil
+}
+
+// 목록 조회 query parameter 변환
+func userQueryFrom(c echo.Context) (*model.UserQuery, error) {
+	query := &model.UserQuery{
+		Sort:   c.QueryParam("sort"),
+		Name:   c.QueryParam("name"),
+		Status: model.UserStatus(c.QueryParam("status")),
+	}
+
+	if query.Status != "" && !query.Status.Valid() {
+		return nil, fmt.Errorf("status must be active, disabled or locked")
+	}
+
+	if query.Sort == "" {
+		query.Sort = "id"
+	}
+	if !model.ValidUserSort(query.Sort) {
+		return nil, fmt.Errorf("unknown sort field %q", query.Sort)
+	}
+
+	switch c.QueryParam("order") {
+	case "", "asc":
+	case "desc":
+		query.Desc = true
+	default:
+		return nil, fmt.Errorf("order must be asc or desc")
+	}
+
+	var err error
+	if query.Page, err = positiveParam(c, "page"); err != nil {
+		return nil, err
+	}
+	if query.Limit, err = positiveParam(c, "limit"); err != nil {
+		return nil, err
+	}
+	if query.Limit > model.MaxUserLimit {
+		return nil, fmt.Errorf("limit must be at most %d", model.MaxUserLimit)
+	}
+
+	if cursor := c.QueryParam("cursor"); cursor != "" {
+		if query.After, err = model.DecodeUserCursor(cursor, query.Sort, query.Desc); err != nil {
+			return nil, err
+		}
+	}
+
+	for name, target := range map[string]**int{"minAge": &query.MinAge, "maxAge": &query.MaxAge} {
+		v := c.QueryParam(name)
+		if v == "" {
+			continue
+		}
+		age, err := strconv.Atoi(v)
+		if err != nil || age < 0 {
+			return nil, fmt.Errorf("%s must be a non-negative integer", name)
+		}
+		*target = &age
+	}
+
+	for name, target := range map[string]**time.Time{"bornAfter": &query.BornAfter, "bornBefore": &query.BornBefore} {
+		v := c.QueryParam(name)
+		if v == "" {
+			continue
+		}
+		t, err := parseDate(v)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a date (2006-01-02) or RFC3339 time", name)
+		}
+		*target = &t
+	}
+
+	return query, nil
+}
+
+// 없으면 0, 있으면 1 이상의 정수
+func positiveParam(c echo.Context, name string) (int, error) {
+	v := c.QueryParam(name)
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("%s must be a positive integer", name)
+	}
+	return n, nil
+}
+
+func parseDate(v string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, nil
 	}
-	return c.JSON(http.StatusInternalServerError, nil)
+	return time.Parse("2006-01-02", v)
 }
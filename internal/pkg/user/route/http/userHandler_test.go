@@ -2,12 +2,18 @@ package http
 
 import (
 	"backend/config"
+	"backend/internal/pkg/domain"
 	"backend/internal/pkg/model"
+	"backend/internal/pkg/problem"
+	"backend/internal/pkg/security"
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
-	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -17,24 +23,26 @@ import (
 
 func TestUserSqlite(t *testing.T) {
 
-	// test 완료 후 기존 file db 삭제
-	os.Remove("./gorm.db")
-
-	// test를 위한 echo/cfg/handelr 생성 및 설정
+	// test를 위한 echo/cfg/handelr 생성 및 설정, db 는 test 마다 새로 생성
 	e := echo.New()
-	// sqlite db path config에서 불러올 수 없어서 강제 지정
 	cfg := config.Config{
-		SqliteDBPath: "./gorm.db",
+		SqliteDBPath: filepath.Join(t.TempDir(), "gorm.db"),
 	}
 	// user handler 생성
-	h := NewUserHandler(e, cfg)
+	db, err := model.NewDBHandler(cfg)
+	assert.NoError(t, err)
+	h := NewUserHandler(e, cfg, db, domain.NewAuditPublisher(&domain.LogMessageSender{}, cfg))
 
 	// 1. test createUser
 	// user 생성, domain package 의 json 형태로 변경 가능한 User struct
-	user := &model.User{
+	user := &model.UserRequest{
 		Name:     "a",
+		Username: "user.a",
+		Email:    " A@Example.com",
 		Age:      18,
-		Birthday: time.Now(),
+		Birthday: birthdayAt(18),
+		Password: "secret",
+		Roles:    model.Roles{security.RoleDeveloper},
 	}
 	// json marshaling
 	body, _ := json.Marshal(user)
@@ -54,10 +62,17 @@ func TestUserSqlite(t *testing.T) {
 		assert.Equal(t, http.StatusCreated, rec.Code)
 	}
 
-	user = &model.User{
+	// 생성된 사용자 반환, password 는 응답에 포함되지 않음
+	assert.NotContains(t, rec.Body.String(), "password")
+	res := &model.UserResponse{}
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(res))
+	assert.Equal(t, uint(1), res.ID)
+	assert.Equal(t, "a@example.com", res.Email)
+	assert.Equal(t, model.UserActive, res.Status)
+
+	user = &model.UserRequest{
 		Name:     "b",
-		Age:      19,
-		Birthday: time.Now(),
+		Birthday: birthdayAt(19),
 	}
 	// json marshaling
 	body, _ = json.Marshal(user)
@@ -77,6 +92,28 @@ func TestUserSqlite(t *testing.T) {
 		assert.Equal(t, http.StatusCreated, rec.Code)
 	}
 
+	// 알 수 없는 role, 이름 누락, 잘못된 입력은 422, 같은 이름, email 은 409
+	for body, code := range map[string]int{
+		`{"name":"c","roles":["root"]}`:                          http.StatusUnprocessableEntity,
+		`{"age":3}`:                                              http.StatusUnprocessableEntity,
+		`{"name":"c","email":"c"}`:                               http.StatusUnprocessableEntity,
+		`{"name":"c","username":"c"}`:                            http.StatusUnprocessableEntity,
+		`{"name":"c","status":"deleted"}`:                        http.StatusUnprocessableEntity,
+		`{"name":"c","age":3,"birthday":"2000-01-01T00:00:00Z"}`: http.StatusUnprocessableEntity,
+		`{"name":"b"}`:                                           http.StatusConflict,
+		`{"name":"c","email":"a@example.com"}`:                   http.StatusConflict,
+	} {
+		req = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		rec = httptest.NewRecorder()
+		req.Header.Set("Content-Type", "application/json")
+		c = e.NewContext(req, rec)
+
+		if assert.NoError(t, h.createUser(c)) {
+			assert.Equal(t, code, rec.Code, body)
+			assert.Equal(t, problem.ContentType, rec.Header().Get(echo.HeaderContentType))
+		}
+	}
+
 	// 2. 조회 테스트(all)
 	req = httptest.NewRequest(http.MethodGet, "/", nil)
 	rec = httptest.NewRecorder()
@@ -88,12 +125,16 @@ func TestUserSqlite(t *testing.T) {
 	}
 
 	// rec 에서 읽어올 struct 생성
-	users := []*model.User{}
+	page := &model.UserPage{}
 
-	// json decoder를 이용하여 decoding 반환 값이 domain user의 slice형태
-	err := json.NewDecoder(rec.Body).Decode(&users)
+	// json decoder를 이용하여 decoding 반환 값이 items 에 domain user의 slice형태
+	err = json.NewDecoder(rec.Body).Decode(page)
 	assert.NoError(t, err)
+	users := page.Items
+	assert.Equal(t, int64(2), page.Total)
 	assert.Equal(t, 2, len(users))
+	assert.Equal(t, model.Roles{security.RoleDeveloper}, users[0].Roles)
+	assert.Equal(t, 19, users[1].Age)
 
 	for _, v := range users {
 		t.Log(v.ID, v.Name, v.Age, v.Birthday)
@@ -112,14 +153,23 @@ func TestUserSqlite(t *testing.T) {
 		assert.Equal(t, http.StatusOK, rec.Code)
 	}
 
+	// password 는 hash 로만 저장되고 응답에 포함되지 않음
+	assert.NotContains(t, rec.Body.String(), "password")
+
 	// rec 에서 읽어올 struct 생성
-	user = &model.User{}
+	res = &model.UserResponse{}
+
+	// json decoder를 이용하여 decoding 반환 값이 user 응답
+	err = json.NewDecoder(rec.Body).Decode(res)
+	assert.NoError(t, err)
+	assert.Equal(t, "a", res.Name)
+	assert.Equal(t, "user.a", res.Username)
+	assert.Equal(t, 18, res.Age)
 
-	// json decoder를 이용하여 decoding 반환 값이 domain user
-	err = json.NewDecoder(rec.Body).Decode(user)
+	stored, err := h.db.GetUserByName(context.Background(), "a")
 	assert.NoError(t, err)
-	assert.Equal(t, "a", user.Name)
-	assert.Equal(t, 18, int(user.Age))
+	assert.NotEqual(t, "secret", stored.PasswordHash)
+	assert.True(t, security.CheckPassword(stored.PasswordHash, "secret"))
 
 	// for _, v := range users {
 	// 	t.Log(v.ID, v.Name, v.Age, v.Birthday)
@@ -138,9 +188,28 @@ func TestUserSqlite(t *testing.T) {
 		assert.Equal(t, http.StatusOK, rec.Code)
 	}
 
+	// 삭제된 사용자 조회, 삭제, 수정은 404
+	for _, handler := range []echo.HandlerFunc{h.getUsersById, h.deleteUsersById, h.updateUserById} {
+		req = httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{"name":"d"}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec = httptest.NewRecorder()
+
+		c = e.NewContext(req, rec)
+		c.SetPath("/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+
+		if assert.NoError(t, handler(c)) {
+			assert.Equal(t, http.StatusNotFound, rec.Code)
+			p := &problem.Problem{}
+			assert.NoError(t, json.NewDecoder(rec.Body).Decode(p))
+			assert.Equal(t, http.StatusNotFound, p.Status)
+		}
+	}
+
 	// 5. 업데이트 테스트 by id
 
-	body, _ = json.Marshal(&model.User{Name: "bbb", Age: 45, Birthday: time.Now()})
+	body, _ = json.Marshal(&model.UserRequest{Name: "bbb", Age: 45, Birthday: birthdayAt(45)})
 
 	req = httptest.NewRequest(http.MethodPut, "/", bytes.NewReader(body))
 	rec = httptest.NewRecorder()
@@ -171,13 +240,127 @@ func TestUserSqlite(t *testing.T) {
 	}
 
 	// rec 에서 읽어올 struct 생성
-	user = &model.User{}
+	res = &model.UserResponse{}
+
+	// json decoder를 이용하여 decoding 반환 값이 user 응답
+	err = json.NewDecoder(rec.Body).Decode(res)
+	assert.NoError(t, err)
+	assert.Equal(t, "bbb", res.Name)
+	assert.Equal(t, 45, res.Age)
+
+	// 저장된 생일과 맞지 않는 나이로 수정은 422
+	req = httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{"age":30}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec = httptest.NewRecorder()
+
+	c = e.NewContext(req, rec)
+	c.SetPath("/:id")
+	c.SetParamNames("id")
+	c.SetParamValues("2")
+
+	if assert.NoError(t, h.updateUserById(c)) {
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	}
+}
+
+// age 살이 되는 생일
+func birthdayAt(age int) *time.Time {
+	birthday := time.Now().AddDate(-age, 0, -1)
+	return &birthday
+}
+
+func TestUserList(t *testing.T) {
 
-	// json decoder를 이용하여 decoding 반환 값이 domain user
-	err = json.NewDecoder(rec.Body).Decode(user)
+	e := echo.New()
+	cfg := config.Config{
+		SqliteDBPath: filepath.Join(t.TempDir(), "gorm.db"),
+	}
+	db, err := model.NewDBHandler(cfg)
 	assert.NoError(t, err)
-	assert.Equal(t, "bbb", user.Name)
-	assert.Equal(t, 45, int(user.Age))
+	h := NewUserHandler(e, cfg, db, domain.NewAuditPublisher(&domain.LogMessageSender{}, cfg))
+
+	// 나이는 생일로 계산, 뒤의 사용자일수록 한 살씩 많음
+	birthday := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	age := time.Now().UTC().Year() - 2000
+	for i, name := range []string{"Alice", "bob", "Carol", "dave", "al_ex"} {
+		assert.NoError(t, h.db.AddUser(context.Background(), &model.User{Name: name, Birthday: birthday.AddDate(-i, 0, 0)}))
+	}
+	assert.NoError(t, h.db.UpdateUserById(context.Background(), 4, &model.User{Status: model.UserDisabled}))
+
+	list := func(query string) (*httptest.ResponseRecorder, *model.UserPage) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/user?"+query, nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		assert.NoError(t, h.getUsers(c))
+		page := &model.UserPage{}
+		if rec.Code == http.StatusOK {
+			assert.NoError(t, json.NewDecoder(rec.Body).Decode(page))
+		}
+		return rec, page
+	}
+	names := func(page *model.UserPage) []string {
+		result := []string{}
+		for _, u := range page.Items {
+			result = append(result, u.Name)
+		}
+		return result
+	}
+
+	// 1. page, limit
+	rec, page := list("limit=2&page=2")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, int64(5), page.Total)
+	assert.Equal(t, 2, page.Page)
+	assert.Equal(t, []string{"Carol", "dave"}, names(page))
+	assert.NotEmpty(t, page.NextCursor)
+	assert.Contains(t, rec.Header().Get("Link"), `rel="next"`)
+
+	// 2. 이름 부분 일치는 대소문자 구분 없고, _ 는 문자 그대로 검색
+	_, page = list("name=AL")
+	assert.Equal(t, []string{"Alice", "al_ex"}, names(page))
+	_, page = list("name=l_")
+	assert.Equal(t, []string{"al_ex"}, names(page))
+
+	// 3. 나이, 생일 범위
+	_, page = list(fmt.Sprintf("minAge=%d&maxAge=%d", age+1, age+3))
+	assert.Equal(t, []string{"bob", "Carol", "dave"}, names(page))
+	_, page = list("bornAfter=1998-01-01&bornBefore=2000-01-01")
+	assert.Equal(t, []string{"bob", "Carol"}, names(page))
+	assert.Equal(t, int64(2), page.Total)
+
+	// 4. 정렬과 cursor, 마지막 페이지에는 next 없음
+	rec, page = list("sort=age&order=desc&limit=2")
+	assert.Equal(t, []string{"al_ex", "dave"}, names(page))
+	assert.Contains(t, page.Next, "cursor=")
+
+	rec, page = list(strings.TrimPrefix(page.Next, "/api/v1/user?"))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, []string{"Carol", "bob"}, names(page))
+	assert.Equal(t, 0, page.Page)
+
+	rec, page = list(strings.TrimPrefix(page.Next, "/api/v1/user?"))
+	assert.Equal(t, []string{"Alice"}, names(page))
+	assert.Empty(t, rec.Header().Get("Link"))
+	assert.Empty(t, page.NextCursor)
+	assert.Empty(t, page.Next)
+
+	// 5. 상태
+	_, page = list("status=disabled")
+	assert.Equal(t, []string{"dave"}, names(page))
+	_, page = list("status=active")
+	assert.Equal(t, int64(4), page.Total)
+
+	// 6. 잘못된 parameter 는 400
+	for _, query := range []string{
+		"page=0", "limit=101", "limit=x", "sort=password", "order=up",
+		"minAge=-1", "bornAfter=yesterday", "cursor=broken", "status=deleted",
+		// 정렬 조건이 다른 cursor
+		"sort=name&cursor=" + (&model.UserCursor{Sort: "age", Value: json.RawMessage("1"), ID: 1}).Encode(),
+	} {
+		rec, _ = list(query)
+		assert.Equal(t, http.StatusBadRequest, rec.Code, query)
+	}
 }
 
 func TestUserPostgres(t *testing.T) {
@@ -200,14 +383,18 @@ func TestUserPostgres(t *testing.T) {
 	}
 
 	// user handler 생성
-	h := NewUserHandler(e, cfg)
+	db, err := model.NewDBHandler(cfg)
+	if !assert.NoError(t, err) {
+		return
+	}
+	h := NewUserHandler(e, cfg, db, domain.NewAuditPublisher(&domain.LogMessageSender{}, cfg))
 
 	// 1. test createUser
 	// user 생성, domain package 의 json 형태로 변경 가능한 User struct
-	user := &model.User{
+	user := &model.UserRequest{
 		Name:     "a",
 		Age:      18,
-		Birthday: time.Now(),
+		Birthday: birthdayAt(18),
 	}
 	// json marshaling
 	body, _ := json.Marshal(user)
@@ -227,10 +414,10 @@ func TestUserPostgres(t *testing.T) {
 		assert.Equal(t, http.StatusCreated, rec.Code)
 	}
 
-	user = &model.User{
+	user = &model.UserRequest{
 		Name:     "b",
 		Age:      19,
-		Birthday: time.Now(),
+		Birthday: birthdayAt(19),
 	}
 	// json marshaling
 	body, _ = json.Marshal(user)
@@ -261,11 +448,13 @@ func TestUserPostgres(t *testing.T) {
 	}
 
 	// rec 에서 읽어올 struct 생성
-	users := []*model.User{}
+	page := &model.UserPage{}
 
-	// json decoder를 이용하여 decoding 반환 값이 domain user의 slice형태
-	err := json.NewDecoder(rec.Body).Decode(&users)
+	// json decoder를 이용하여 decoding 반환 값이 items 에 domain user의 slice형태
+	err = json.NewDecoder(rec.Body).Decode(page)
 	assert.NoError(t, err)
+	users := page.Items
+	assert.Equal(t, int64(2), page.Total)
 	assert.Equal(t, 2, len(users))
 
 	for _, v := range users {
@@ -286,13 +475,13 @@ func TestUserPostgres(t *testing.T) {
 	}
 
 	// rec 에서 읽어올 struct 생성
-	user = &model.User{}
+	res := &model.UserResponse{}
 
-	// json decoder를 이용하여 decoding 반환 값이 domain user
-	err = json.NewDecoder(rec.Body).Decode(user)
+	// json decoder를 이용하여 decoding 반환 값이 user 응답
+	err = json.NewDecoder(rec.Body).Decode(res)
 	assert.NoError(t, err)
-	assert.Equal(t, "a", user.Name)
-	assert.Equal(t, 18, int(user.Age))
+	assert.Equal(t, "a", res.Name)
+	assert.Equal(t, 18, res.Age)
 
 	// for _, v := range users {
 	// 	t.Log(v.ID, v.Name, v.Age, v.Birthday)
@@ -313,7 +502,7 @@ func TestUserPostgres(t *testing.T) {
 
 	// 5. 업데이트 테스트 by id
 
-	body, _ = json.Marshal(&model.User{Name: "bbb", Age: 45, Birthday: time.Now()})
+	body, _ = json.Marshal(&model.UserRequest{Name: "bbb", Age: 45, Birthday: birthdayAt(45)})
 
 	req = httptest.NewRequest(http.MethodPut, "/", bytes.NewReader(body))
 	rec = httptest.NewRecorder()
@@ -344,11 +533,11 @@ func TestUserPostgres(t *testing.T) {
 	}
 
 	// rec 에서 읽어올 struct 생성
-	user = &model.User{}
+	res = &model.UserResponse{}
 
-	// json decoder를 이용하여 decoding 반환 값이 domain user
-	err = json.NewDecoder(rec.Body).Decode(user)
+	// json decoder를 이용하여 decoding 반환 값이 user 응답
+	err = json.NewDecoder(rec.Body).Decode(res)
 	assert.NoError(t, err)
-	assert.Equal(t, "bbb", user.Name)
-	assert.Equal(t, 45, int(user.Age))
+	assert.Equal(t, "bbb", res.Name)
+	assert.Equal(t, 45, res.Age)
 }
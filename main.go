@@ -4,14 +4,19 @@ package main
 // go install github.com/swaggo/swag/cmd/swag
 import (
 	"backend/config"
+	"backend/internal/pkg/domain"
 	githubRoute "backend/internal/pkg/github/route/http"
+	k8sRoute "backend/internal/pkg/k8s/route/http"
+	"backend/internal/pkg/model"
 	"backend/internal/pkg/security"
 	securityRoute "backend/internal/pkg/security/route/http"
 	userRoute "backend/internal/pkg/user/route/http"
 
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"os"
 	"time"
 
 	_ "backend/docs"
@@ -39,26 +44,36 @@ func NewApp() *fx.App {
 		fx.Provide(
 			config.New,
 			NewEcho,
+			model.NewDBHandler,
+			domain.NewMessageSender,
+			domain.NewAuditPublisher,
+			domain.NewMessageConsumer,
 		),
 		fx.Invoke(
 			userRoute.NewUserHandler,
 			githubRoute.NewGitHandler,
+			k8sRoute.NewK8sHandler,
+			closeMessageSender,
+			closeAuditPublisher,
+			closeDB,
 			serve,
+			registerMessageHandlers,
 			security.WebSecurityConfig,
 			securityRoute.NewSecurityHandler,
 		),
 	)
 }
 
-func serve(lifecycle fx.Lifecycle, echo *echo.Echo, cfg config.Config) {
+func serve(lifecycle fx.Lifecycle, echo *echo.Echo, cfg config.Config, db model.DBHandler) {
 	echo.GET("/swagger/*", swagger.WrapHandler)
+	security.Public(echo.GET("/readyz", readiness(db)))
 
 	lifecycle.Append(fx.Hook{
 		OnStart: func(ctx context.Context) error {
 			fmt.Println(cfg.Listen)
 
 			go func() {
-				if err := echo.Start(cfg.Listen); err != nil {
+				if err := echo.Start(cfg.Listen); err != nil && !errors.Is(err, http.ErrServerClosed) {
 					panic(fmt.Sprintf("echo.Start() err=%v", err))
 				}
 			}()
@@ -67,7 +82,53 @@ func serve(lifecycle fx.Lifecycle, echo *echo.Echo, cfg config.Config) {
 		},
 		OnStop: func(ctx context.Context) error {
 			fmt.Println("Stopping monitoring server.")
-			return nil
+			// 처리 중인 요청 완료 후 종료, db 는 이후 closeDB 에서 정리
+			return echo.Shutdown(ctx)
+		},
+	})
+}
+
+// 종료 시 db connection pool 정리, serve 보다 먼저 등록하여 server 종료 후 실행
+func closeDB(lifecycle fx.Lifecycle, db model.DBHandler) {
+	lifecycle.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			return db.Close()
+		},
+	})
+}
+
+// 종료 시 전송 대기 중인 kafka message flush, audit publisher 보다 먼저 등록하여 audit event 전송 후 실행
+func closeMessageSender(lifecycle fx.Lifecycle, sender domain.MessageSender) {
+	lifecycle.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			return sender.Close()
+		},
+	})
+}
+
+// 종료 시 queue 에 남은 audit event 전송, serve 보다 먼저 등록하여 server 종료 후 실행
+func closeAuditPublisher(lifecycle fx.Lifecycle, audit *domain.AuditPublisher) {
+	lifecycle.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			return audit.Close()
+		},
+	})
+}
+
+// topic 별 handler 등록 후 app 시작 시 구독, 종료 시 consumer group 종료
+func registerMessageHandlers(lifecycle fx.Lifecycle, consumer domain.MessageConsumer, cfg config.Config) {
+	consumerCfg := cfg.Kafka.Consumer
+
+	if consumerCfg.AlertOwner != "" && consumerCfg.AlertRepo != "" {
+		consumer.Handle(consumerCfg.AlertTopic, domain.NewAlertIssueHandler(domain.NewGitClientHandler(cfg), consumerCfg.AlertOwner, consumerCfg.AlertRepo))
+	}
+
+	lifecycle.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			return consumer.Start()
+		},
+		OnStop: func(ctx context.Context) error {
+			return consumer.Close()
 		},
 	})
 }
@@ -90,10 +151,17 @@ func main() {
 	defer cancel()
 	if err := app.Start(startCtx); err != nil {
 		fmt.Println(err)
+		os.Exit(1)
 	}
 
 	<-app.Done()
 
+	// signal 수신 후 OnStop hook(db, kafka 정리) 실행
+	stopCtx, stopCancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer stopCancel()
+	if err := app.Stop(stopCtx); err != nil {
+		fmt.Println(err)
+	}
 }
 
 // @Summary		Show the status of server.
@@ -109,3 +177,24 @@ func HealthCheck(c echo.Context) error {
 		"data": "Server is up and running",
 	})
 }
+
+// @Summary		Readiness
+// @Description	check database connection, 503 when ping fails
+// @Tags			root
+// @Produce		json
+// @Success		200	{object}	map[string]interface{}
+// @Failure		503	{object}	map[string]interface{}
+// @Router			/readyz [get]
+func readiness(db model.DBHandler) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if err := db.Ping(c.Request().Context()); err != nil {
+			return c.JSON(http.StatusServiceUnavailable, map[string]interface{}{
+				"status": "unavailable",
+				"error":  err.Error(),
+			})
+		}
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"status": "ready",
+		})
+	}
+}